@@ -2,7 +2,6 @@ module mazegenerator
 
 go 1.24.4
 
-require (
-	golang.org/x/image v0.28.0 // indirect
-	golang.org/x/text v0.26.0 // indirect
-)
+require golang.org/x/image v0.28.0
+
+require golang.org/x/text v0.26.0 // indirect
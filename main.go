@@ -1,8 +1,13 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"image/color"
 	"log"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"mazegenerator/maze"
@@ -15,20 +20,75 @@ const (
 
 	// Maximum retries for maze generation
 	MaxRetries = 5
+
+	// Largest image rendered, about 400 MB in memory
+	MaxImagePixels = 100_000_000
 )
 
 func main() {
+	// Parse command-line flags
+	width := flag.Int("width", DefaultWidth, "maze width in cells")
+	height := flag.Int("height", DefaultHeight, "maze height in cells")
+	seed := flag.Int64("seed", 0, "random seed for reproducible mazes (random if not set)")
+	output := flag.String("output", "", "output PNG filename (timestamped if not set)")
+	retries := flag.Int("retries", MaxRetries, "maximum generation retries")
+	wallColorHex := flag.String("wallcolor", "#000000", "wall color as a hex string like #102040")
+	pathColorHex := flag.String("pathcolor", "#ffffff", "path and background color as a hex string like #fff8e7")
+	flag.Parse()
+
+	if *width <= 0 || *height <= 0 || *retries <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: width, height, and retries must be positive")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	wallColor, err := parseHexColor(*wallColorHex)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid -wallcolor: %v\n", err)
+		os.Exit(2)
+	}
+	pathColor, err := parseHexColor(*pathColorHex)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid -pathcolor: %v\n", err)
+		os.Exit(2)
+	}
+
+	// Only use the seed if it was explicitly provided
+	seedSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			seedSet = true
+		}
+	})
+
 	fmt.Println("Maze Generator")
 	fmt.Println("==============")
 
 	// Create generator and renderer
-	generator := maze.NewGenerator()
-	renderer := maze.NewDefaultRenderer()
+	var generator *maze.Generator
+	if seedSet {
+		generator = maze.NewGeneratorWithSeed(*seed)
+		fmt.Printf("Using seed %d\n", *seed)
+	} else {
+		generator = maze.NewGenerator()
+	}
+	config := maze.DefaultRenderConfig()
+	config.WallColor = wallColor
+	config.PathColor = pathColor
+	config.HeaderColor = pathColor
+	renderer := maze.NewRenderer(config)
 
-	fmt.Printf("Generating %dx%d maze...\n", DefaultWidth, DefaultHeight)
+	fmt.Printf("Generating %dx%d maze...\n", *width, *height)
+	if cells := *width * *height; cells > maze.LargeMazeCells {
+		fmt.Fprintf(os.Stderr, "Warning: a %dx%d maze has %d cells; generation may be slow and use a lot of memory\n",
+			*width, *height, cells)
+	}
 
 	// Generate maze with validation
-	mazeObj := generator.GenerateWithValidation(DefaultWidth, DefaultHeight, MaxRetries)
+	mazeObj, err := generator.GenerateWithValidation(*width, *height, *retries)
+	if err != nil {
+		log.Fatalf("Error generating maze: %v", err)
+	}
 
 	fmt.Println("Placing start and finish points...")
 
@@ -40,18 +100,24 @@ func main() {
 		fmt.Println("✓ Path verified from start to finish!")
 	}
 
-	// Generate filename with timestamp
-	timestamp := time.Now().Format("20060102_150405")
-	filename := fmt.Sprintf("maze_%s.png", timestamp)
+	// Generate filename with timestamp unless one was given
+	filename := *output
+	if filename == "" {
+		timestamp := time.Now().Format("20060102_150405")
+		filename = fmt.Sprintf("maze_%s.png", timestamp)
+	}
 
 	fmt.Printf("Rendering maze to PNG (%s)...\n", filename)
 
 	// Get image dimensions for user info
-	width, height := renderer.GetImageDimensions(mazeObj)
-	fmt.Printf("Image dimensions: %dx%d pixels\n", width, height)
+	imgWidth, imgHeight := renderer.GetImageDimensions(mazeObj)
+	fmt.Printf("Image dimensions: %dx%d pixels\n", imgWidth, imgHeight)
+	if err := renderer.CheckRenderable(mazeObj, MaxImagePixels); err != nil {
+		log.Fatalf("Error: maze is too large to render: %v", err)
+	}
 
 	// Render to PNG
-	err := renderer.RenderToPNG(mazeObj, filename)
+	err = renderer.RenderToPNG(mazeObj, filename)
 	if err != nil {
 		log.Fatalf("Error rendering maze: %v", err)
 	}
@@ -64,3 +130,18 @@ func main() {
 	fmt.Println("Legend is shown at the top of the maze.")
 	fmt.Println("Ready to print and solve!")
 }
+
+// parseHexColor parses a color written as #rrggbb (the leading # is optional)
+func parseHexColor(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) != 6 {
+		return color.RGBA{}, fmt.Errorf("%q is not a 6-digit hex color like #102040", s)
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("%q is not a 6-digit hex color like #102040", s)
+	}
+
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 255}, nil
+}
@@ -0,0 +1,284 @@
+package maze
+
+// Analyzer computes structural statistics about a maze
+type Analyzer struct{}
+
+// NewAnalyzer creates a new maze analyzer
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{}
+}
+
+// CountJunctions returns the number of junction cells (three or more open directions)
+func (a *Analyzer) CountJunctions(maze *Maze) int {
+	return len(a.junctionCells(maze))
+}
+
+// PathLength returns the number of steps in the shortest path from start to finish,
+// or -1 if no path exists
+func (a *Analyzer) PathLength(maze *Maze) int {
+	path := NewValidator().FindPath(maze)
+	return len(path) - 1
+}
+
+// DeadEndCount returns the number of dead-end cells (exactly three walls)
+func (a *Analyzer) DeadEndCount(maze *Maze) int {
+	return len(a.DeadEnds(maze))
+}
+
+// DeadEnds returns the positions of all dead-end cells (exactly three walls)
+func (a *Analyzer) DeadEnds(maze *Maze) []Point {
+	if maze == nil {
+		return nil
+	}
+
+	var deadEnds []Point
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			if openDirections(maze, maze.GetCell(x, y)) == 1 {
+				deadEnds = append(deadEnds, Point{x, y})
+			}
+		}
+	}
+	return deadEnds
+}
+
+// Junctions returns the positions of all junction cells (zero or one walls, so three or more open directions)
+func (a *Analyzer) Junctions(maze *Maze) []Point {
+	return a.junctionCells(maze)
+}
+
+// DirectionBias counts the interior passages of the maze by axis: horizontal
+// is the number of removed East/West walls and vertical the number of removed
+// North/South walls, each shared wall counted once. Disabled cells and diagonal
+// passages are not counted. Comparing the two shows whether an algorithm
+// favors corridors along one axis; in a perfect non-diagonal maze they sum to
+// one less than the number of enabled cells, while a diagonal maze's uncounted
+// diagonal passages make the sum smaller.
+func (a *Analyzer) DirectionBias(maze *Maze) (horizontal, vertical int) {
+	if maze == nil {
+		return 0, 0
+	}
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+			if cell.Disabled {
+				continue
+			}
+			if east := maze.GetNeighbor(cell, East); east != nil && !east.Disabled && !cell.HasWall(East) {
+				horizontal++
+			}
+			if south := maze.GetNeighbor(cell, South); south != nil && !south.Disabled && !cell.HasWall(South) {
+				vertical++
+			}
+		}
+	}
+	return horizontal, vertical
+}
+
+// deadEndWeight is how much a dead end counts relative to one solution step
+const deadEndWeight = 2.0
+
+// Difficulty returns a score combining solution length and dead ends, normalized by area:
+//
+//	(pathLength + 2*deadEnds) / (width*height)
+//
+// Higher scores indicate harder mazes. Mazes without a solution score 0.
+func (a *Analyzer) Difficulty(maze *Maze) float64 {
+	if maze == nil || maze.Width*maze.Height == 0 {
+		return 0
+	}
+
+	pathLength := a.PathLength(maze)
+	if pathLength < 0 {
+		return 0
+	}
+
+	score := float64(pathLength) + deadEndWeight*float64(a.DeadEndCount(maze))
+	return score / float64(maze.Width*maze.Height)
+}
+
+// Complexity returns a score for ranking mazes by how hard they are to solve:
+//
+//	(pathLength + 2*deadEnds) / (width*height) + 1/averageCorridorLength
+//
+// The first term is Difficulty. The second rewards short corridors, since
+// every corridor ends in a decision or a dead end; averageCorridorLength is
+// the mean number of steps between junctions and dead ends. Scores are
+// deterministic for a given maze, so a batch can be sorted by them. Mazes
+// without a solution score 0.
+func (a *Analyzer) Complexity(maze *Maze) float64 {
+	difficulty := a.Difficulty(maze)
+	if difficulty == 0 {
+		return 0
+	}
+
+	if corridor := a.averageCorridorLength(maze); corridor > 0 {
+		difficulty += 1 / corridor
+	}
+	return difficulty
+}
+
+// averageCorridorLength returns the mean number of steps in the corridors
+// between cells that are not simple passages (junctions and dead ends).
+// Every passage between two cells belongs to exactly one corridor, so this is
+// the number of passages divided by the number of corridors. A maze that is a
+// single loop counts as one corridor, and a maze without passages returns 0.
+func (a *Analyzer) averageCorridorLength(maze *Maze) float64 {
+	if maze == nil {
+		return 0
+	}
+
+	passageEnds, corridorEnds := 0, 0
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+			degree := 0
+			for _, dir := range AllDirections() {
+				if neighbor := maze.GetNeighbor(cell, dir); neighbor != nil && maze.CanMove(cell, neighbor) {
+					degree++
+				}
+			}
+
+			passageEnds += degree
+			if degree != 2 {
+				corridorEnds += degree
+			}
+		}
+	}
+
+	if passageEnds == 0 {
+		return 0
+	}
+	if corridorEnds == 0 {
+		return float64(passageEnds / 2)
+	}
+	return float64(passageEnds) / float64(corridorEnds)
+}
+
+// junctionCells returns the positions of all cells with three or more open directions
+func (a *Analyzer) junctionCells(maze *Maze) []Point {
+	if maze == nil {
+		return nil
+	}
+
+	var junctions []Point
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			if openDirections(maze, maze.GetCell(x, y)) >= 3 {
+				junctions = append(junctions, Point{x, y})
+			}
+		}
+	}
+
+	return junctions
+}
+
+// openDirections returns the number of sides of the cell without a wall that
+// lead to another cell. Outer edges opened for an entrance or exit, like those
+// isEdgeOpening reports, are not counted.
+func openDirections(maze *Maze, cell *Cell) int {
+	open := 0
+	for _, dir := range AllDirections() {
+		if cell.HasWall(dir) {
+			continue
+		}
+		if neighbor := maze.GetNeighbor(cell, dir); neighbor != nil && !neighbor.Disabled {
+			open++
+		}
+	}
+	return open
+}
+
+// Weights used by EstimateSolveSeconds
+const (
+	secondsPerPathStep    = 0.5  // Time to trace one step of the solution
+	secondsPerJunction    = 1.5  // Time spent deciding at each junction
+	secondsPerDeadEndCell = 0.25 // Time lost per cell of blind alley explored
+)
+
+// EstimateSolveSeconds returns a rough estimate of how long a person takes to solve the maze.
+// The estimate is a weighted sum:
+//
+//	0.5*solutionSteps + 1.5*junctions + 0.25*deadEndCells
+//
+// where deadEndCells is the total length of all dead-end corridors. It is meant
+// as a consistent relative measure for labeling worksheets, not a precise prediction.
+func (a *Analyzer) EstimateSolveSeconds(maze *Maze) float64 {
+	if maze == nil {
+		return 0
+	}
+
+	steps := max(a.PathLength(maze), 0)
+
+	deadEndCells := 0
+	for _, depth := range a.deadEndDepths(maze) {
+		deadEndCells += depth
+	}
+
+	return secondsPerPathStep*float64(steps) +
+		secondsPerJunction*float64(a.CountJunctions(maze)) +
+		secondsPerDeadEndCell*float64(deadEndCells)
+}
+
+// LongestDeadEnd returns the length in steps of the longest blind alley, measured
+// from a dead-end cell back to the nearest junction. It returns 0 for mazes
+// without any dead ends, such as fully braided ones.
+func (a *Analyzer) LongestDeadEnd(maze *Maze) int {
+	longest := 0
+	for _, depth := range a.deadEndDepths(maze) {
+		longest = max(longest, depth)
+	}
+	return longest
+}
+
+// deadEndDepths returns, for every dead-end cell, the number of steps along its
+// corridor until a junction or another dead end is reached
+func (a *Analyzer) deadEndDepths(maze *Maze) []int {
+	if maze == nil {
+		return nil
+	}
+
+	var depths []int
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+			if openDirections(maze, cell) == 1 {
+				depths = append(depths, a.corridorLength(maze, cell))
+			}
+		}
+	}
+
+	return depths
+}
+
+// corridorLength walks from a dead-end cell along its corridor and returns the
+// number of steps taken before reaching a cell that is not a simple passage
+func (a *Analyzer) corridorLength(maze *Maze, start *Cell) int {
+	var previous *Cell
+	current := start
+	steps := 0
+
+	for {
+		// Find the next open neighbor that isn't where we came from
+		var next *Cell
+		for _, dir := range AllDirections() {
+			neighbor := maze.GetNeighbor(current, dir)
+			if neighbor != nil && neighbor != previous && maze.CanMove(current, neighbor) {
+				next = neighbor
+				break
+			}
+		}
+		if next == nil {
+			return steps
+		}
+
+		previous, current = current, next
+		steps++
+
+		// Stop at junctions and at the far end of an isolated corridor
+		if openDirections(maze, current) != 2 {
+			return steps
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package maze
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEdgeOpeningsDoNotChangeDeadEnds(t *testing.T) {
+	a := NewAnalyzer()
+	for _, seed := range testSeeds {
+		g := NewGeneratorWithSeed(seed)
+		m := g.Generate(12, 9)
+		before := a.DeadEnds(m)
+
+		// Open the entrance on a dead end in the top row, if there is one
+		i := slices.IndexFunc(before, func(p Point) bool { return p.Y == 0 })
+		if i < 0 {
+			continue
+		}
+		if err := g.SetEntrance(m, North, before[i].X); err != nil {
+			t.Fatal(err)
+		}
+		if after := a.DeadEnds(m); !slices.Equal(after, before) {
+			t.Errorf("seed %d: dead ends changed from %v to %v after opening %v", seed, before, after, before[i])
+		}
+	}
+}
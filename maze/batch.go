@@ -0,0 +1,94 @@
+package maze
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"path/filepath"
+	"strconv"
+)
+
+// GenerateBatch creates count validated mazes, where maze i is generated from
+// seed baseSeed+i, so the whole set can be reproduced from the base seed.
+// A maze that duplicates an earlier one is regenerated from the same
+// generator until it is unique; mazes too small to have enough distinct
+// layouts may still repeat once the retries run out. Generation fails like
+// GenerateWithValidation.
+func GenerateBatch(count, width, height int, baseSeed int64) ([]*Maze, error) {
+	mazes := make([]*Maze, 0, max(count, 0))
+	seen := make(map[string]bool, max(count, 0))
+
+	for i := 0; i < count; i++ {
+		g := NewGeneratorWithSeed(baseSeed + int64(i))
+
+		maze, err := g.GenerateWithValidation(width, height, defaultRetries)
+		for attempt := 0; err == nil && attempt < defaultRetries && seen[string(maze.ToRLE())]; attempt++ {
+			maze, err = g.GenerateWithValidation(width, height, defaultRetries)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("generating maze %d: %w", i, err)
+		}
+
+		seen[string(maze.ToRLE())] = true
+		mazes = append(mazes, maze)
+	}
+
+	return mazes, nil
+}
+
+// BatchFilename returns the file name of maze index in a batch of count mazes,
+// such as maze_007.png. The index is zero-padded to at least three digits and
+// to enough digits for the whole batch to sort in order.
+func BatchFilename(index, count int) string {
+	digits := max(3, len(strconv.Itoa(count-1)))
+	return fmt.Sprintf("maze_%0*d.png", digits, index)
+}
+
+// RenderBatchToPNG renders each maze to its own PNG file in dir,
+// named by BatchFilename in the order given
+func (r *Renderer) RenderBatchToPNG(mazes []*Maze, dir string) error {
+	for i, maze := range mazes {
+		if err := r.RenderToPNG(maze, filepath.Join(dir, BatchFilename(i, len(mazes)))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderGridToPNG renders the mazes side by side on a single PNG, filling a
+// grid cols wide row by row, such as four mazes on one worksheet. Each maze is
+// drawn as RenderToPNG would, with its own legend, and centered in a slot as
+// large as the largest maze. Slots are separated by the configured padding.
+func (r *Renderer) RenderGridToPNG(mazes []*Maze, cols int, filename string) error {
+	if len(mazes) == 0 {
+		return renderError(filename, errors.New("no mazes to lay out"))
+	}
+	if cols < 1 {
+		return renderError(filename, fmt.Errorf("grid needs at least 1 column, got %d", cols))
+	}
+
+	images := make([]*image.RGBA, len(mazes))
+	slotWidth, slotHeight := 0, 0
+	for i, maze := range mazes {
+		images[i] = r.fitToSize(maze).createImage(maze)
+		slotWidth = max(slotWidth, images[i].Bounds().Dx())
+		slotHeight = max(slotHeight, images[i].Bounds().Dy())
+	}
+
+	cols = min(cols, len(mazes))
+	rows := (len(mazes) + cols - 1) / cols
+	spacing := r.config.Padding
+	canvas := image.NewRGBA(image.Rect(0, 0,
+		cols*slotWidth+(cols-1)*spacing, rows*slotHeight+(rows-1)*spacing))
+	draw.Draw(canvas, canvas.Bounds(), &image.Uniform{r.config.PathColor}, image.Point{}, draw.Src)
+
+	for i, img := range images {
+		slotX := (i % cols) * (slotWidth + spacing)
+		slotY := (i / cols) * (slotHeight + spacing)
+		offset := image.Pt(slotX+(slotWidth-img.Bounds().Dx())/2, slotY+(slotHeight-img.Bounds().Dy())/2)
+		draw.Draw(canvas, img.Bounds().Add(offset), img, image.Point{}, draw.Src)
+	}
+
+	return r.writePNG(canvas, filename)
+}
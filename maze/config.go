@@ -0,0 +1,237 @@
+package maze
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"image/png"
+)
+
+// Validate checks that the configuration can produce a well-formed image.
+// Sizes must be positive, offsets non-negative, and the main colors set.
+func (c RenderConfig) Validate() error {
+	var errs []error
+
+	// Sizes must be at least 1, offsets at least 0
+	sizes := []struct {
+		name     string
+		value    int
+		minValue int
+	}{
+		{"CellSize", c.CellSize, 1},
+		{"CellWidth", c.CellWidth, 0},
+		{"CellHeight", c.CellHeight, 0},
+		{"WallThickness", c.WallThickness, 1},
+		{"LegendFontSize", c.LegendFontSize, 1},
+		{"ImageWidth", c.ImageWidth, 0},
+		{"ImageHeight", c.ImageHeight, 0},
+		{"Padding", c.Padding, 0},
+		{"HeaderHeight", c.HeaderHeight, 0},
+		{"SolutionThickness", c.SolutionThickness, 0},
+		{"SolutionArrowSpacing", c.SolutionArrowSpacing, 0},
+		{"HandDrawnJitter", c.HandDrawnJitter, 0},
+	}
+	for _, size := range sizes {
+		if size.value < size.minValue {
+			errs = append(errs, fmt.Errorf("%s must be at least %d, got %d", size.name, size.minValue, size.value))
+		}
+	}
+
+	colors := []struct {
+		name  string
+		value color.Color
+	}{
+		{"WallColor", c.WallColor},
+		{"PathColor", c.PathColor},
+		{"TextColor", c.TextColor},
+	}
+	for _, entry := range colors {
+		if entry.value == nil {
+			errs = append(errs, fmt.Errorf("%s must be set", entry.name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// withDefaults returns a copy of the configuration with every invalid field
+// replaced by its value from DefaultRenderConfig
+func (c RenderConfig) withDefaults() RenderConfig {
+	defaults := DefaultRenderConfig()
+
+	if c.CellSize <= 0 {
+		c.CellSize = defaults.CellSize
+	}
+	if c.CellWidth < 0 {
+		c.CellWidth = defaults.CellWidth
+	}
+	if c.CellHeight < 0 {
+		c.CellHeight = defaults.CellHeight
+	}
+	if c.WallThickness <= 0 {
+		c.WallThickness = defaults.WallThickness
+	}
+	if c.LegendFontSize <= 0 {
+		c.LegendFontSize = defaults.LegendFontSize
+	}
+	if c.ImageWidth < 0 {
+		c.ImageWidth = defaults.ImageWidth
+	}
+	if c.ImageHeight < 0 {
+		c.ImageHeight = defaults.ImageHeight
+	}
+	if c.Padding < 0 {
+		c.Padding = defaults.Padding
+	}
+	if c.HeaderHeight < 0 {
+		c.HeaderHeight = defaults.HeaderHeight
+	}
+	if c.SolutionThickness < 0 {
+		c.SolutionThickness = defaults.SolutionThickness
+	}
+	if c.SolutionArrowSpacing < 0 {
+		c.SolutionArrowSpacing = defaults.SolutionArrowSpacing
+	}
+	if c.HandDrawnJitter < 0 {
+		c.HandDrawnJitter = defaults.HandDrawnJitter
+	}
+	if c.StartMarker == DefaultMarker {
+		c.StartMarker = defaults.StartMarker
+	}
+	if c.FinishMarker == DefaultMarker {
+		c.FinishMarker = defaults.FinishMarker
+	}
+	if c.WallColor == nil {
+		c.WallColor = defaults.WallColor
+	}
+	if c.PathColor == nil {
+		c.PathColor = defaults.PathColor
+	}
+	if c.TextColor == nil {
+		c.TextColor = defaults.TextColor
+	}
+
+	return c
+}
+
+// RenderConfigBuilder constructs a RenderConfig starting from the defaults
+type RenderConfigBuilder struct {
+	config RenderConfig
+}
+
+// NewRenderConfigBuilder creates a builder initialized with DefaultRenderConfig
+func NewRenderConfigBuilder() *RenderConfigBuilder {
+	return &RenderConfigBuilder{config: DefaultRenderConfig()}
+}
+
+// WithCellSize sets the size of each cell in pixels
+func (b *RenderConfigBuilder) WithCellSize(size int) *RenderConfigBuilder {
+	b.config.CellSize = size
+	return b
+}
+
+// WithCellDimensions sets the width and height of each cell in pixels, for rectangular cells
+func (b *RenderConfigBuilder) WithCellDimensions(width, height int) *RenderConfigBuilder {
+	b.config.CellWidth = width
+	b.config.CellHeight = height
+	return b
+}
+
+// WithWallThickness sets the thickness of walls in pixels
+func (b *RenderConfigBuilder) WithWallThickness(thickness int) *RenderConfigBuilder {
+	b.config.WallThickness = thickness
+	return b
+}
+
+// WithPadding sets the padding around the maze in pixels
+func (b *RenderConfigBuilder) WithPadding(padding int) *RenderConfigBuilder {
+	b.config.Padding = padding
+	return b
+}
+
+// WithHeaderHeight sets the height of the legend header in pixels
+func (b *RenderConfigBuilder) WithHeaderHeight(height int) *RenderConfigBuilder {
+	b.config.HeaderHeight = height
+	return b
+}
+
+// WithLegendFontSize sets the legend font size multiplier
+func (b *RenderConfigBuilder) WithLegendFontSize(size int) *RenderConfigBuilder {
+	b.config.LegendFontSize = size
+	return b
+}
+
+// WithFontPath sets the TrueType font used for text
+func (b *RenderConfigBuilder) WithFontPath(path string) *RenderConfigBuilder {
+	b.config.FontPath = path
+	return b
+}
+
+// WithColors sets the wall, path, and text colors
+func (b *RenderConfigBuilder) WithColors(wall, path, text color.Color) *RenderConfigBuilder {
+	b.config.WallColor = wall
+	b.config.PathColor = path
+	b.config.TextColor = text
+	return b
+}
+
+// WithScaleBar enables the scale bar footer with the given label
+func (b *RenderConfigBuilder) WithScaleBar(label string) *RenderConfigBuilder {
+	b.config.ScaleBar = true
+	b.config.ScaleLabel = label
+	return b
+}
+
+// WithCompressionLevel sets the PNG compression level, such as png.BestCompression
+func (b *RenderConfigBuilder) WithCompressionLevel(level png.CompressionLevel) *RenderConfigBuilder {
+	b.config.CompressionLevel = level
+	return b
+}
+
+// Build returns the configuration, or an error if it is invalid
+func (b *RenderConfigBuilder) Build() (RenderConfig, error) {
+	if err := b.config.Validate(); err != nil {
+		return RenderConfig{}, err
+	}
+	return b.config, nil
+}
+
+// defaultDPI is the print resolution DefaultRenderConfig is tuned for
+const defaultDPI = 300
+
+// minPaperCellSize is the smallest cell size RenderConfigForPaper will choose,
+// so that walls and markers stay visible on very dense mazes
+const minPaperCellSize = 6
+
+// RenderConfigForPaper returns a configuration that fills a page of the given
+// physical size at the given resolution with a mazeW by mazeH maze. Padding,
+// header, and legend scale with the DPI from their 300 DPI defaults, and the
+// cell size and wall thickness are chosen so the maze fits the remaining area.
+func RenderConfigForPaper(widthInches, heightInches float64, dpi int, mazeW, mazeH int) RenderConfig {
+	config := DefaultRenderConfig()
+	if dpi <= 0 || mazeW <= 0 || mazeH <= 0 {
+		return config
+	}
+
+	pageWidth := int(widthInches * float64(dpi))
+	pageHeight := int(heightInches * float64(dpi))
+
+	// Scale the fixed margins from the 300 DPI defaults
+	defaults := DefaultRenderConfig()
+	config.Padding = defaults.Padding * dpi / defaultDPI
+	config.HeaderHeight = defaults.HeaderHeight * dpi / defaultDPI
+	config.LegendFontSize = max(defaults.LegendFontSize*dpi/defaultDPI, 1)
+
+	// Fit the cells into the space left inside the padding and below the header
+	availableWidth := pageWidth - 2*config.Padding
+	availableHeight := pageHeight - 2*config.Padding - config.HeaderHeight
+
+	// Walls keep the default proportion to the cell size and add one thickness to the maze size
+	wallRatio := float64(defaults.WallThickness) / float64(defaults.CellSize)
+	cellSize := min(float64(availableWidth)/(float64(mazeW)+wallRatio), float64(availableHeight)/(float64(mazeH)+wallRatio))
+
+	config.CellSize = max(int(cellSize), minPaperCellSize)
+	config.WallThickness = max(int(float64(config.CellSize)*wallRatio), 1)
+
+	return config
+}
@@ -0,0 +1,405 @@
+package maze
+
+import (
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// ToRLE encodes the maze as run-length-encoded wall data.
+// The output starts with the dimensions and start/finish coordinates as varints,
+// then a flags uvarint (rleDiagonal for diagonal mazes), the number of
+// multi-goal Finishes and their coordinates, followed by (run length, cell
+// value) uvarint pairs covering the cells in row-major order. A cell value
+// holds the wall bits in its low byte, including the diagonal walls of a
+// diagonal maze, and rleDisabled for cells outside a mask.
+func (m *Maze) ToRLE() []byte {
+	var data []byte
+	for _, v := range []int{m.Width, m.Height, m.Start.X, m.Start.Y, m.Finish.X, m.Finish.Y} {
+		data = binary.AppendVarint(data, int64(v))
+	}
+	var flags uint64
+	walls := allWalls
+	if m.Diagonal {
+		flags |= rleDiagonal
+		walls |= diagonalWalls
+	}
+	data = binary.AppendUvarint(data, flags)
+	data = binary.AppendUvarint(data, uint64(len(m.Finishes)))
+	for _, finish := range m.Finishes {
+		data = binary.AppendVarint(data, int64(finish.X))
+		data = binary.AppendVarint(data, int64(finish.Y))
+	}
+
+	// Emit a run each time the cell value changes
+	var current uint64
+	run := 0
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			cell := m.GetCell(x, y)
+			value := uint64(cell.Walls & walls)
+			if cell.Disabled {
+				value |= rleDisabled
+			}
+			if run > 0 && value != current {
+				data = binary.AppendUvarint(data, uint64(run))
+				data = binary.AppendUvarint(data, current)
+				run = 0
+			}
+			current = value
+			run++
+		}
+	}
+	if run > 0 {
+		data = binary.AppendUvarint(data, uint64(run))
+		data = binary.AppendUvarint(data, current)
+	}
+
+	return data
+}
+
+// maxRLECells bounds the size of a decoded maze so that a corrupt or hostile
+// header cannot make FromRLE allocate an arbitrarily large grid
+const maxRLECells = 1 << 24
+
+// rleDisabled marks a disabled cell in an RLE cell value, above the wall bits
+const rleDisabled = 1 << 8
+
+// rleDiagonal is the RLE header flag for a diagonal maze
+const rleDiagonal = 1 << 0
+
+// rleRun is one decoded (run length, cell value) pair
+type rleRun struct {
+	length   int
+	bits     uint8
+	disabled bool
+}
+
+// FromRLE decodes a maze previously encoded with ToRLE. The header must describe
+// at most maxRLECells cells and the runs must cover exactly width*height cells;
+// both are checked before the maze is allocated.
+func FromRLE(data []byte) (*Maze, error) {
+	// Read the header values
+	header := make([]int, 6)
+	for i := range header {
+		v, n := binary.Varint(data)
+		if n <= 0 {
+			return nil, errors.New("rle: truncated header")
+		}
+		header[i] = int(v)
+		data = data[n:]
+	}
+
+	width, height := header[0], header[1]
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("rle: invalid dimensions %dx%d", width, height)
+	}
+	if width > maxRLECells/height {
+		return nil, fmt.Errorf("rle: %dx%d maze exceeds %d cells", width, height, maxRLECells)
+	}
+
+	flags, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, errors.New("rle: truncated flags")
+	}
+	if flags&^rleDiagonal != 0 {
+		return nil, fmt.Errorf("rle: unknown flags %#x", flags)
+	}
+	data = data[n:]
+	walls := allWalls
+	if flags&rleDiagonal != 0 {
+		walls |= diagonalWalls
+	}
+
+	// Read the multi-goal finishes; each takes at least two bytes
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, errors.New("rle: truncated finish count")
+	}
+	data = data[n:]
+	if count > uint64(len(data)/2) {
+		return nil, errors.New("rle: truncated finishes")
+	}
+	finishes := make([]Point, count)
+	for i := range finishes {
+		x, n := binary.Varint(data)
+		if n <= 0 {
+			return nil, errors.New("rle: truncated finishes")
+		}
+		y, k := binary.Varint(data[n:])
+		if k <= 0 {
+			return nil, errors.New("rle: truncated finishes")
+		}
+		finishes[i] = Point{int(x), int(y)}
+		data = data[n+k:]
+	}
+
+	// Decode and total the runs before allocating any cells
+	total := width * height
+	var runs []rleRun
+	sum := 0
+	for len(data) > 0 {
+		run, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errors.New("rle: truncated run")
+		}
+		value, k := binary.Uvarint(data[n:])
+		if k <= 0 {
+			return nil, errors.New("rle: truncated run")
+		}
+		data = data[n+k:]
+
+		if value&^rleDisabled&^uint64(walls) != 0 {
+			return nil, fmt.Errorf("rle: invalid cell value %#x", value)
+		}
+		if run == 0 || run > uint64(total-sum) {
+			return nil, fmt.Errorf("rle: run of %d cells overflows %dx%d maze", run, width, height)
+		}
+		runs = append(runs, rleRun{int(run), uint8(value), value&rleDisabled != 0})
+		sum += int(run)
+	}
+	if sum != total {
+		return nil, fmt.Errorf("rle: expected %d cells, got %d", total, sum)
+	}
+
+	maze := NewMaze(width, height)
+	maze.Start = Point{header[2], header[3]}
+	maze.Finish = Point{header[4], header[5]}
+	maze.Diagonal = flags&rleDiagonal != 0
+	if len(finishes) > 0 {
+		maze.Finishes = finishes
+	}
+
+	// Expand the runs into cells in row-major order
+	index := 0
+	for _, run := range runs {
+		for i := 0; i < run.length; i++ {
+			cell := maze.Cells[index/width][index%width]
+			cell.Walls = run.bits
+			cell.Disabled = run.disabled
+			cell.Visited = true
+			index++
+		}
+	}
+
+	return maze, nil
+}
+
+// ToGraph returns the passage graph of the maze as an adjacency list: every
+// enabled cell, including the start and finish, maps to the cells it can move
+// to directly, in direction order. Cells with no passages map to an empty list.
+func (m *Maze) ToGraph() map[Point][]Point {
+	graph := make(map[Point][]Point, m.Width*m.Height)
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			cell := m.GetCell(x, y)
+			if cell.Disabled {
+				continue
+			}
+
+			neighbors := []Point{}
+			for _, dir := range m.directions() {
+				if neighbor := m.GetNeighbor(cell, dir); neighbor != nil && m.CanMove(cell, neighbor) {
+					neighbors = append(neighbors, Point{neighbor.X, neighbor.Y})
+				}
+			}
+			graph[Point{x, y}] = neighbors
+		}
+	}
+	return graph
+}
+
+// pathStepJSON is the serialized form of one step of a path
+type pathStepJSON struct {
+	Step int `json:"step"`
+	X    int `json:"x"`
+	Y    int `json:"y"`
+}
+
+// ExportPath writes a path, such as the result of FindPath, to w for use in
+// other tools. The "csv" format writes a step,x,y header followed by one row
+// per step, numbered from 0; the "json" format writes an array of objects with
+// the same fields. Any other format is an error.
+func ExportPath(path []Point, w io.Writer, format string) error {
+	switch format {
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"step", "x", "y"})
+		for i, p := range path {
+			cw.Write([]string{strconv.Itoa(i), strconv.Itoa(p.X), strconv.Itoa(p.Y)})
+		}
+		cw.Flush()
+		return cw.Error()
+	case "json":
+		steps := make([]pathStepJSON, len(path))
+		for i, p := range path {
+			steps[i] = pathStepJSON{Step: i, X: p.X, Y: p.Y}
+		}
+		return json.NewEncoder(w).Encode(steps)
+	default:
+		return fmt.Errorf("export path: unknown format %q, want csv or json", format)
+	}
+}
+
+// mazeJSON is the serialized form of a maze.
+// Walls holds, for each row and column, the directions that have a wall,
+// Disabled lists the cells excluded by a mask, and Diagonal marks a diagonal
+// maze whose walls include the four diagonal sides.
+type mazeJSON struct {
+	Width    int             `json:"width"`
+	Height   int             `json:"height"`
+	Start    Point           `json:"start"`
+	Finish   Point           `json:"finish"`
+	Finishes []Point         `json:"finishes,omitempty"`
+	Disabled []Point         `json:"disabled,omitempty"`
+	Diagonal bool            `json:"diagonal,omitempty"`
+	Walls    [][][]Direction `json:"walls"`
+}
+
+// MarshalJSON encodes the maze dimensions, start, finishes, mask, and walls
+func (m *Maze) MarshalJSON() ([]byte, error) {
+	data := mazeJSON{
+		Width:    m.Width,
+		Height:   m.Height,
+		Start:    m.Start,
+		Finish:   m.Finish,
+		Finishes: m.Finishes,
+		Diagonal: m.Diagonal,
+		Walls:    make([][][]Direction, m.Height),
+	}
+
+	for y := 0; y < m.Height; y++ {
+		data.Walls[y] = make([][]Direction, m.Width)
+		for x := 0; x < m.Width; x++ {
+			walls := []Direction{}
+			for _, dir := range m.directions() {
+				if m.GetCell(x, y).HasWall(dir) {
+					walls = append(walls, dir)
+				}
+			}
+			data.Walls[y][x] = walls
+			if m.GetCell(x, y).Disabled {
+				data.Disabled = append(data.Disabled, Point{x, y})
+			}
+		}
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON decodes a maze previously encoded with MarshalJSON
+func (m *Maze) UnmarshalJSON(b []byte) error {
+	var data mazeJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	if data.Width <= 0 || data.Height <= 0 {
+		return fmt.Errorf("json: invalid dimensions %dx%d", data.Width, data.Height)
+	}
+	if len(data.Walls) != data.Height {
+		return fmt.Errorf("json: expected %d rows of walls, got %d", data.Height, len(data.Walls))
+	}
+
+	maze := NewMaze(data.Width, data.Height)
+	maze.Diagonal = data.Diagonal
+	maze.Start = data.Start
+	maze.Finish = data.Finish
+	if len(data.Finishes) > 0 {
+		maze.SetFinishes(data.Finishes)
+	}
+
+	for y, row := range data.Walls {
+		if len(row) != data.Width {
+			return fmt.Errorf("json: expected %d cells in row %d, got %d", data.Width, y, len(row))
+		}
+		for x, walls := range row {
+			cell := maze.GetCell(x, y)
+			cell.Visited = true
+			cell.Walls = 0
+			for _, dir := range walls {
+				cell.SetWall(dir, true)
+			}
+		}
+	}
+
+	for _, p := range data.Disabled {
+		cell := maze.GetCell(p.X, p.Y)
+		if cell == nil {
+			return fmt.Errorf("json: disabled cell (%d,%d) is outside the maze", p.X, p.Y)
+		}
+		cell.Disabled = true
+	}
+
+	*m = *maze
+	return nil
+}
+
+// Save writes the maze to a JSON file
+func Save(m *Maze, filename string) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0o644)
+}
+
+// Load reads a maze from a JSON file written by Save
+func Load(filename string) (*Maze, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Maze{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ToWallGrid converts the maze into a boolean tile grid where true is a wall.
+// The grid has 2*Height+1 rows and 2*Width+1 columns: cell (x, y) sits at
+// [2*y+1][2*x+1] and the walls around it occupy the neighboring even indices.
+// Outer walls are opened at the start and finish, matching the rendered image.
+func ToWallGrid(maze *Maze) [][]bool {
+	rows := 2*maze.Height + 1
+	cols := 2*maze.Width + 1
+
+	// Start with every tile open, then fill in corner posts and walls
+	grid := make([][]bool, rows)
+	for row := range grid {
+		grid[row] = make([]bool, cols)
+		for col := range grid[row] {
+			grid[row][col] = row%2 == 0 && col%2 == 0
+		}
+	}
+
+	// Row and column offset from a cell tile to each of its wall tiles
+	offsets := map[Direction][2]int{
+		North: {-1, 0},
+		East:  {0, 1},
+		South: {1, 0},
+		West:  {0, -1},
+	}
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+			row, col := 2*y+1, 2*x+1
+
+			for _, dir := range AllDirections() {
+				if cell.HasWall(dir) && !isEdgeOpening(maze, cell, dir) {
+					grid[row+offsets[dir][0]][col+offsets[dir][1]] = true
+				}
+			}
+		}
+	}
+
+	return grid
+}
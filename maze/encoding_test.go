@@ -0,0 +1,125 @@
+package maze
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestToWallGridBorderOpensOnlyAtEntranceAndExit(t *testing.T) {
+	const width, height = 8, 6
+	g := NewGeneratorWithSeed(1)
+	m := g.Generate(width, height)
+	if err := g.SetEntrance(m, North, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.SetExit(m, East, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	grid := ToWallGrid(m)
+	rows, cols := 2*height+1, 2*width+1
+	if len(grid) != rows || len(grid[0]) != cols {
+		t.Fatalf("grid is %dx%d, want %dx%d", len(grid[0]), len(grid), cols, rows)
+	}
+
+	openings := map[[2]int]bool{
+		{0, 2*2 + 1}:        true, // Entrance above (2,0)
+		{2*4 + 1, cols - 1}: true, // Exit right of (7,4)
+	}
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			if row != 0 && row != rows-1 && col != 0 && col != cols-1 {
+				continue
+			}
+			if want := !openings[[2]int{row, col}]; grid[row][col] != want {
+				t.Errorf("border tile [%d][%d] = %v, want %v", row, col, grid[row][col], want)
+			}
+		}
+	}
+}
+
+func TestFromRLERoundTrip(t *testing.T) {
+	for _, seed := range testSeeds {
+		m := testMaze(t, seed, 12, 9)
+		decoded, err := FromRLE(m.ToRLE())
+		if err != nil {
+			t.Fatalf("seed %d: FromRLE: %v", seed, err)
+		}
+		if !m.Equal(decoded) {
+			t.Errorf("seed %d: decoded maze differs from the original", seed)
+		}
+	}
+}
+
+// rleHeader encodes the given header values the way ToRLE does, with no
+// multi-goal finishes
+func rleHeader(values ...int) []byte {
+	var data []byte
+	for _, v := range values {
+		data = binary.AppendVarint(data, int64(v))
+	}
+	return binary.AppendUvarint(data, 0)
+}
+
+func TestFromRLERejectsBadSizes(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"too many cells", binary.AppendUvarint(rleHeader(1<<20, 1<<20, 0, 0, 0, 0), 1)},
+		{"too few runs", binary.AppendUvarint(binary.AppendUvarint(rleHeader(3, 3, 0, 0, 2, 2), 8), 0xF)},
+		{"run overflow", binary.AppendUvarint(binary.AppendUvarint(rleHeader(3, 3, 0, 0, 2, 2), 10), 0xF)},
+	}
+	for _, tt := range tests {
+		if _, err := FromRLE(tt.data); err == nil {
+			t.Errorf("%s: FromRLE succeeded, want an error", tt.name)
+		}
+	}
+}
+
+func TestFromRLEKeepsFinishes(t *testing.T) {
+	m := testMaze(t, 1, 10, 10)
+	m.SetFinishes([]Point{{9, 9}, {0, 9}, {9, 0}})
+	decoded, err := FromRLE(m.ToRLE())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Equal(decoded) {
+		t.Errorf("decoded Finishes %v, want %v", decoded.Finishes, m.Finishes)
+	}
+}
+
+func TestFromRLEKeepsMask(t *testing.T) {
+	mask := [][]bool{
+		{true, true, true},
+		{true, false, true},
+		{true, true, true},
+	}
+	m := NewGeneratorWithSeed(1).GenerateMasked(mask)
+	decoded, err := FromRLE(m.ToRLE())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Equal(decoded) {
+		t.Error("decoded masked maze differs from the original")
+	}
+	if !decoded.GetCell(1, 1).Disabled {
+		t.Error("masked-out cell (1, 1) was decoded as enabled")
+	}
+}
+
+func TestFromRLEKeepsDiagonalWalls(t *testing.T) {
+	for _, seed := range testSeeds {
+		m := NewGeneratorWithSeed(seed).GenerateDiagonal(6, 6)
+		decoded, err := FromRLE(m.ToRLE())
+		if err != nil {
+			t.Fatalf("seed %d: FromRLE: %v", seed, err)
+		}
+		if !decoded.Diagonal || !m.Equal(decoded) {
+			t.Errorf("seed %d: decoded diagonal maze differs from the original", seed)
+		}
+		if !NewValidator().HasPath(decoded) {
+			t.Errorf("seed %d: decoded diagonal maze has no path", seed)
+		}
+	}
+}
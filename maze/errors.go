@@ -0,0 +1,55 @@
+package maze
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidDimensions is returned when a maze is requested with a width or height below 1
+var ErrInvalidDimensions = errors.New("maze: width and height must be at least 1")
+
+// ErrOutOfRange is returned when a requested cell lies outside the maze grid
+var ErrOutOfRange = errors.New("maze: point outside the grid")
+
+// ErrNoValidPath is returned when generation cannot connect the start to the finish
+var ErrNoValidPath = errors.New("maze: no path from start to finish")
+
+// ErrPathLengthNotMet is returned when generation cannot reach the requested solution length
+var ErrPathLengthNotMet = errors.New("maze: no solution within tolerance of the target length")
+
+// RenderError reports a failure while rendering or writing an image.
+// Err is the underlying cause, such as the error from creating the file,
+// and is available through errors.Is and errors.As.
+type RenderError struct {
+	Filename string // Output file, or empty when rendering to a writer
+	Err      error
+}
+
+// Error describes the failure and the file it happened on
+func (e *RenderError) Error() string {
+	if e.Filename == "" {
+		return "render: " + e.Err.Error()
+	}
+	return fmt.Sprintf("render %s: %v", e.Filename, e.Err)
+}
+
+// Unwrap returns the underlying cause
+func (e *RenderError) Unwrap() error {
+	return e.Err
+}
+
+// renderError wraps err in a RenderError for filename, passing nil through
+func renderError(filename string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &RenderError{Filename: filename, Err: err}
+}
+
+// checkDimensions returns ErrInvalidDimensions unless both dimensions are at least 1
+func checkDimensions(width, height int) error {
+	if width < 1 || height < 1 {
+		return fmt.Errorf("%w, got %dx%d", ErrInvalidDimensions, width, height)
+	}
+	return nil
+}
@@ -1,15 +1,26 @@
 package maze
 
 import (
+	"context"
 	cryptorand "crypto/rand"
+	"fmt"
+	"image"
 	"math/big"
 	"math/rand"
 	"time"
 )
 
-// Generator handles maze generation using recursive backtracking
+// Generator handles maze generation using recursive backtracking.
+// A Generator is not safe for concurrent use; use a GeneratorPool to generate in parallel.
 type Generator struct {
-	rng *rand.Rand
+	rng  *rand.Rand
+	seed int64
+
+	// DisableShuffle makes recursive backtracking start in the top-left corner
+	// and try neighbors in the fixed order North, East, South, West, so Generate
+	// and GenerateInto produce the same maze for a given size regardless of seed.
+	// Other algorithms still draw from the random source.
+	DisableShuffle bool
 }
 
 // NewGenerator creates a new maze generator with a random seed
@@ -21,41 +32,133 @@ func NewGenerator() *Generator {
 		seed = big.NewInt(time.Now().UnixNano())
 	}
 
+	return NewGeneratorWithSeed(seed.Int64())
+}
+
+// NewGeneratorWithSeed creates a new maze generator with a fixed seed.
+// Generators created with the same seed produce identical mazes.
+func NewGeneratorWithSeed(seed int64) *Generator {
 	return &Generator{
-		rng: rand.New(rand.NewSource(seed.Int64())),
+		rng:  rand.New(rand.NewSource(seed)),
+		seed: seed,
 	}
 }
 
+// Seed returns the seed the generator was created with. Every maze a generator
+// makes advances the same random stream, so a new generator with this seed
+// reproduces the first maze; a later maze is only reproduced by repeating the
+// same calls in the same order.
+func (g *Generator) Seed() int64 {
+	return g.seed
+}
+
 // Generate creates a new maze using recursive backtracking algorithm
 func (g *Generator) Generate(width, height int) *Maze {
 	maze := NewMaze(width, height)
+	g.carve(maze)
+	return maze
+}
 
-	// Start from a random cell
-	startX := g.rng.Intn(width)
-	startY := g.rng.Intn(height)
-	startCell := maze.GetCell(startX, startY)
+// GenerateFrom creates a new maze using recursive backtracking, carving outward
+// from the cell at (originX, originY) instead of a random one. With
+// DisableShuffle set the result depends only on the size and origin.
+// It returns an error wrapping ErrInvalidDimensions for an empty grid and
+// ErrOutOfRange if the origin lies outside the grid.
+func (g *Generator) GenerateFrom(width, height, originX, originY int) (*Maze, error) {
+	if err := checkDimensions(width, height); err != nil {
+		return nil, err
+	}
+	if originX < 0 || originX >= width || originY < 0 || originY >= height {
+		return nil, fmt.Errorf("%w: origin (%d,%d) in %dx%d maze", ErrOutOfRange, originX, originY, width, height)
+	}
 
-	// Use recursive backtracking to generate the maze
-	g.generateRecursive(maze, startCell)
+	maze := NewMaze(width, height)
+	g.backtrack(maze, maze.GetCell(originX, originY), nil)
+	return maze, nil
+}
 
-	return maze
+// GenerateInto re-carves an existing maze in place with recursive backtracking,
+// reusing its cells instead of allocating new ones. Every wall is restored and
+// the start, finish, visited flags, any mask, and the Diagonal flag are cleared
+// first, so the result matches Generate for the same seed and dimensions.
+func (g *Generator) GenerateInto(maze *Maze) {
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.Cells[y][x]
+			cell.Visited = false
+			cell.Disabled = false
+			cell.Walls = allWalls
+		}
+	}
+	maze.Diagonal = false
+	maze.Start = Point{}
+	maze.Finish = Point{}
+	maze.Finishes = nil
+
+	g.carve(maze)
 }
 
-// generateRecursive implements the recursive backtracking algorithm
-func (g *Generator) generateRecursive(maze *Maze, current *Cell) {
-	current.Visited = true
+// carve runs recursive backtracking from a random cell of a maze with every wall intact
+func (g *Generator) carve(maze *Maze) {
+	// Start from a random cell, or the top-left one when shuffling is disabled
+	startCell := maze.GetCell(0, 0)
+	if !g.DisableShuffle {
+		startCell = maze.GetCell(g.rng.Intn(maze.Width), g.rng.Intn(maze.Height))
+	}
 
-	// Get all unvisited neighbors in random order
-	neighbors := g.getUnvisitedNeighbors(maze, current)
-	g.shuffleNeighbors(neighbors)
+	g.backtrack(maze, startCell, nil)
+}
 
-	for _, neighbor := range neighbors {
-		if !neighbor.Visited {
-			// Remove wall between current and neighbor
-			maze.RemoveWall(current, neighbor)
+// backtrack implements recursive backtracking with an explicit stack, so even
+// very large mazes can't overflow the call stack. Each frame makes the same
+// random choices a recursive call would. When straightness is non-nil and
+// returns more than 0 for the cell being entered, the cell straight ahead is
+// tried first with that probability, as in GenerateBiased.
+func (g *Generator) backtrack(maze *Maze, start *Cell, straightness func(cell *Cell) float64) {
+	var stack []stepFrame
+
+	enter := func(from, cell *Cell) {
+		cell.Visited = true
 
-			// Recursively visit the neighbor
-			g.generateRecursive(maze, neighbor)
+		// Get all unvisited neighbors in random order, or fixed order when shuffling is disabled
+		neighbors := g.getUnvisitedNeighbors(maze, cell)
+		if !g.DisableShuffle {
+			g.shuffleNeighbors(neighbors)
+		}
+
+		// Only roll when biased so a straightness of 0 consumes the same random numbers as Generate
+		bias := 0.0
+		if from != nil && straightness != nil {
+			bias = straightness(cell)
+		}
+		if bias > 0 && g.rng.Float64() < bias {
+			ahead := maze.GetCell(2*cell.X-from.X, 2*cell.Y-from.Y)
+			for i, neighbor := range neighbors {
+				if neighbor == ahead {
+					neighbors[0], neighbors[i] = neighbors[i], neighbors[0]
+					break
+				}
+			}
+		}
+
+		stack = append(stack, stepFrame{cell: cell, neighbors: neighbors})
+	}
+
+	enter(nil, start)
+	for len(stack) > 0 {
+		frame := &stack[len(stack)-1]
+		if frame.next == len(frame.neighbors) {
+			// No neighbors left, backtrack
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		neighbor := frame.neighbors[frame.next]
+		frame.next++
+		if !neighbor.Visited {
+			// Remove wall between current and neighbor, then visit it
+			maze.RemoveWall(frame.cell, neighbor)
+			enter(frame.cell, neighbor)
 		}
 	}
 }
@@ -64,8 +167,7 @@ func (g *Generator) generateRecursive(maze *Maze, current *Cell) {
 func (g *Generator) getUnvisitedNeighbors(maze *Maze, cell *Cell) []*Cell {
 	var neighbors []*Cell
 
-	directions := []Direction{North, East, South, West}
-	for _, dir := range directions {
+	for _, dir := range AllDirections() {
 		neighbor := maze.GetNeighbor(cell, dir)
 		if neighbor != nil && !neighbor.Visited {
 			neighbors = append(neighbors, neighbor)
@@ -83,27 +185,110 @@ func (g *Generator) shuffleNeighbors(neighbors []*Cell) {
 	}
 }
 
-// PlaceStartAndFinish randomly places start and finish points in the maze
-// ensuring they are as far apart as possible
+// GenerateBiased creates a new maze using recursive backtracking with a
+// preference for straight corridors. After entering a cell, the carver keeps
+// going in the same direction with probability straightness (0 to 1) when it
+// can. At 0 the output is identical to Generate for the same seed; higher
+// values give longer, straighter corridors that are easier to solve.
+func (g *Generator) GenerateBiased(width, height int, straightness float64) *Maze {
+	maze := NewMaze(width, height)
+
+	// Start from a random cell
+	startX := g.rng.Intn(width)
+	startY := g.rng.Intn(height)
+	g.backtrack(maze, maze.GetCell(startX, startY), func(*Cell) float64 { return straightness })
+
+	return maze
+}
+
+// GenerateProgressive creates a maze that gets harder from the start in the
+// top-left corner to the finish in the bottom-right. A cell's progress t runs
+// from 0 at the start to 1 at the finish along the diagonal, (x+y)/(width+height-2),
+// and its ease is gradient*(1-t) for a gradient from 0 to 1. Carving uses
+// GenerateBiased's straightness bias with the ease of each cell, and every
+// dead end is then opened into a loop with probability equal to its ease. The
+// start area therefore has long straight corridors with few dead ends, while
+// the finish area is twisty and full of them. A gradient of 0 gives the same
+// maze as Generate for the same seed, with the start and finish in the corners.
+func (g *Generator) GenerateProgressive(width, height int, gradient float64) *Maze {
+	gradient = min(max(gradient, 0), 1)
+	maze := NewMaze(width, height)
+
+	ease := func(cell *Cell) float64 {
+		if width+height <= 2 {
+			return gradient
+		}
+		t := float64(cell.X+cell.Y) / float64(width+height-2)
+		return gradient * (1 - t)
+	}
+
+	startX := g.rng.Intn(width)
+	startY := g.rng.Intn(height)
+	g.backtrack(maze, maze.GetCell(startX, startY), ease)
+
+	// Open the dead ends near the start; skipped entirely at 0 so no random numbers are used
+	if gradient > 0 {
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				cell := maze.GetCell(x, y)
+				if openDirections(maze, cell) == 1 && g.rng.Float64() < ease(cell) {
+					g.openDeadEnd(maze, cell)
+				}
+			}
+		}
+	}
+
+	maze.Start = Point{0, 0}
+	maze.Finish = Point{width - 1, height - 1}
+	return maze
+}
+
+// PlaceStartAndFinish places start and finish at the pair of corners that are
+// farthest apart through the maze, measured by BFS path length. In a masked
+// maze each corner is replaced by the nearest enabled cell. Any multi-goal
+// Finishes are cleared.
 func (g *Generator) PlaceStartAndFinish(maze *Maze) {
-	// Try to place start and finish at opposite corners first
 	corners := []Point{
 		{0, 0},                            // Top-left
 		{maze.Width - 1, 0},               // Top-right
 		{0, maze.Height - 1},              // Bottom-left
 		{maze.Width - 1, maze.Height - 1}, // Bottom-right
 	}
+	for i, corner := range corners {
+		if p, ok := nearestEnabled(maze, corner); ok {
+			corners[i] = p
+		}
+	}
 
-	// Shuffle corners for randomness
+	// Shuffle corners for randomness; ties go to the earlier pair in this order
 	for i := len(corners) - 1; i > 0; i-- {
 		j := g.rng.Intn(i + 1)
 		corners[i], corners[j] = corners[j], corners[i]
 	}
 
-	// Try corner pairs first
+	// Evaluate all six corner pairs and keep the farthest connected one
+	validator := NewValidator()
+	bestDistance := -1
+	for i := 0; i < len(corners); i++ {
+		distances := validator.bfsDistances(maze, corners[i])
+		for j := i + 1; j < len(corners); j++ {
+			if d, ok := distances[corners[j]]; ok && d > bestDistance {
+				maze.Start = corners[i]
+				maze.SetFinish(corners[j])
+				bestDistance = d
+			}
+		}
+	}
+
+	if bestDistance >= 0 {
+		return
+	}
+
+	// Fallback: no corners are connected, so use the first pair and let the
+	// caller's validation decide whether to retry
 	if len(corners) >= 2 {
 		maze.Start = corners[0]
-		maze.Finish = corners[1]
+		maze.SetFinish(corners[1])
 		return
 	}
 
@@ -111,12 +296,17 @@ func (g *Generator) PlaceStartAndFinish(maze *Maze) {
 	g.placeRandomStartFinish(maze)
 }
 
-// placeRandomStartFinish places start and finish at random locations
+// placeRandomStartFinish places start and finish at random enabled locations
 func (g *Generator) placeRandomStartFinish(maze *Maze) {
 	// Generate random start position
-	maze.Start = Point{
-		X: g.rng.Intn(maze.Width),
-		Y: g.rng.Intn(maze.Height),
+	for {
+		maze.Start = Point{
+			X: g.rng.Intn(maze.Width),
+			Y: g.rng.Intn(maze.Height),
+		}
+		if !maze.GetCell(maze.Start.X, maze.Start.Y).Disabled {
+			break
+		}
 	}
 
 	// Generate random finish position, ensuring it's different from start
@@ -126,17 +316,106 @@ func (g *Generator) placeRandomStartFinish(maze *Maze) {
 			Y: g.rng.Intn(maze.Height),
 		}
 
-		// Ensure finish is not the same as start
+		// Ensure finish is enabled and not the same as start
+		if maze.GetCell(finish.X, finish.Y).Disabled {
+			continue
+		}
 		if finish.X != maze.Start.X || finish.Y != maze.Start.Y {
-			maze.Finish = finish
+			maze.SetFinish(finish)
 			break
 		}
 	}
 }
 
-// GenerateWithValidation generates a maze and ensures start/finish are connected
-func (g *Generator) GenerateWithValidation(width, height int, maxRetries int) *Maze {
-	for attempt := 0; attempt < maxRetries; attempt++ {
+// GenerateMasked creates a new maze in the shape of a mask using recursive backtracking.
+// The maze is as tall as the mask and as wide as its longest row; cells where
+// the mask is true are carved, while false or missing cells are disabled. The
+// start and finish are then placed among the enabled cells with
+// PlaceStartAndFinish. Parts of the shape that only touch diagonally are
+// carved as separate mazes, so the start and finish may not be connected
+// unless the enabled cells form one orthogonally connected region.
+//
+// A mask with fewer than two enabled cells has no room for a separate start and
+// finish: with one enabled cell both are placed on it, and with none both are
+// left at (0, 0).
+func (g *Generator) GenerateMasked(mask [][]bool) *Maze {
+	width := 0
+	for _, row := range mask {
+		width = max(width, len(row))
+	}
+	maze := NewMaze(width, len(mask))
+
+	// Disabled cells count as visited so the carver never enters them
+	var enabled []*Cell
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+			if x < len(mask[y]) && mask[y][x] {
+				enabled = append(enabled, cell)
+			} else {
+				cell.Disabled = true
+				cell.Visited = true
+			}
+		}
+	}
+	switch len(enabled) {
+	case 0:
+		return maze
+	case 1:
+		enabled[0].Visited = true
+		maze.Start = Point{enabled[0].X, enabled[0].Y}
+		maze.Finish = maze.Start
+		return maze
+	}
+
+	// Carve from a random enabled cell, then from any islands it couldn't reach
+	g.backtrack(maze, enabled[g.rng.Intn(len(enabled))], nil)
+	for _, cell := range enabled {
+		if !cell.Visited {
+			g.backtrack(maze, cell, nil)
+		}
+	}
+
+	g.PlaceStartAndFinish(maze)
+	return maze
+}
+
+// nearestEnabled returns the enabled cell closest to p by Manhattan distance,
+// which is p itself unless the maze is masked, and false if every cell is disabled
+func nearestEnabled(maze *Maze, p Point) (Point, bool) {
+	if cell := maze.GetCell(p.X, p.Y); cell != nil && !cell.Disabled {
+		return p, true
+	}
+
+	best, bestDistance := p, -1
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			if maze.Cells[y][x].Disabled {
+				continue
+			}
+			if d := manhattan(p, Point{x, y}); bestDistance < 0 || d < bestDistance {
+				best, bestDistance = Point{x, y}, d
+			}
+		}
+	}
+	return best, bestDistance >= 0
+}
+
+// LargeMazeCells is the cell count above which generation and validation stop
+// being instant and start using hundreds of megabytes. Generation itself is
+// iterative and has no hard limit; callers may use this to warn their users.
+const LargeMazeCells = 1_000_000
+
+// GenerateWithValidation generates a maze and ensures start/finish are connected.
+// At least one generation attempt is made even if maxRetries is below 1. It
+// returns ErrInvalidDimensions for a width or height below 1, and
+// ErrNoValidPath if no attempt produced a connected start and finish.
+func (g *Generator) GenerateWithValidation(width, height int, maxRetries int) (*Maze, error) {
+	if err := checkDimensions(width, height); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < max(maxRetries, 1); attempt++ {
 		maze := g.Generate(width, height)
 
 		// Try multiple start/finish placements
@@ -146,14 +425,943 @@ func (g *Generator) GenerateWithValidation(width, height int, maxRetries int) *M
 			// Validate that a path exists
 			validator := NewValidator()
 			if validator.HasPath(maze) {
-				return maze
+				return maze, nil
+			}
+		}
+	}
+
+	return nil, ErrNoValidPath
+}
+
+// GenerateWithTargetPathLength generates mazes until one has a solution within
+// tolerance steps of target, measured like Analyzer.PathLength. Each maze is
+// given up to 10 random starts, and for each the finish is chosen at random
+// among the cells whose distance falls within range. At least one maze is
+// generated even if maxAttempts is below 1. It returns ErrInvalidDimensions for
+// a width or height below 1, and ErrPathLengthNotMet if no attempt succeeds.
+func (g *Generator) GenerateWithTargetPathLength(width, height, target, tolerance int, maxAttempts int) (*Maze, error) {
+	if err := checkDimensions(width, height); err != nil {
+		return nil, err
+	}
+	if target < 0 || tolerance < 0 {
+		return nil, fmt.Errorf("target length %d and tolerance %d must not be negative", target, tolerance)
+	}
+
+	validator := NewValidator()
+	for attempt := 0; attempt < max(maxAttempts, 1); attempt++ {
+		maze := g.Generate(width, height)
+
+		for placementAttempt := 0; placementAttempt < 10; placementAttempt++ {
+			maze.Start = Point{g.rng.Intn(width), g.rng.Intn(height)}
+			distances := validator.bfsDistances(maze, maze.Start)
+
+			// Scan in row-major order so the choice depends only on the seed
+			var candidates []Point
+			for y := 0; y < height; y++ {
+				for x := 0; x < width; x++ {
+					p := Point{x, y}
+					if d, ok := distances[p]; ok && p != maze.Start && d >= target-tolerance && d <= target+tolerance {
+						candidates = append(candidates, p)
+					}
+				}
+			}
+			if len(candidates) > 0 {
+				maze.Finish = candidates[g.rng.Intn(len(candidates))]
+				return maze, nil
 			}
 		}
 	}
 
-	// If we get here, something went wrong - return a basic maze anyway
-	// This shouldn't happen with proper maze generation, but it's a safety net
+	return nil, fmt.Errorf("%w: target %d, tolerance %d", ErrPathLengthNotMet, target, tolerance)
+}
+
+// GenerateWideCorridors creates a maze whose corridors are two cells wide.
+// A perfect maze is generated on a grid of half the requested size and each
+// logical cell is then expanded into a 2x2 block of real cells. Odd
+// dimensions are rounded down to the nearest even size, except that dimensions
+// below 2 are rounded up to 2 so the maze holds at least one block.
+func (g *Generator) GenerateWideCorridors(width, height int) *Maze {
+	logicalWidth := max(width/2, 1)
+	logicalHeight := max(height/2, 1)
+	logical := g.Generate(logicalWidth, logicalHeight)
+
+	maze := NewMaze(logicalWidth*2, logicalHeight*2)
+
+	for ly := 0; ly < logicalHeight; ly++ {
+		for lx := 0; lx < logicalWidth; lx++ {
+			logicalCell := logical.GetCell(lx, ly)
+			baseX, baseY := lx*2, ly*2
+
+			// Open up the interior of the 2x2 block
+			topLeft := maze.GetCell(baseX, baseY)
+			topRight := maze.GetCell(baseX+1, baseY)
+			bottomLeft := maze.GetCell(baseX, baseY+1)
+			bottomRight := maze.GetCell(baseX+1, baseY+1)
+			maze.RemoveWall(topLeft, topRight)
+			maze.RemoveWall(bottomLeft, bottomRight)
+			maze.RemoveWall(topLeft, bottomLeft)
+			maze.RemoveWall(topRight, bottomRight)
+
+			// Widen each logical passage east and south across both rows/columns.
+			// North and west passages are handled by the neighboring block.
+			if !logicalCell.HasWall(East) && lx+1 < logicalWidth {
+				maze.RemoveWall(topRight, maze.GetCell(baseX+2, baseY))
+				maze.RemoveWall(bottomRight, maze.GetCell(baseX+2, baseY+1))
+			}
+			if !logicalCell.HasWall(South) && ly+1 < logicalHeight {
+				maze.RemoveWall(bottomLeft, maze.GetCell(baseX, baseY+2))
+				maze.RemoveWall(bottomRight, maze.GetCell(baseX+1, baseY+2))
+			}
+		}
+	}
+
+	// Mark all cells as visited to match the state left by Generate
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			maze.Cells[y][x].Visited = true
+		}
+	}
+
+	g.PlaceStartAndFinish(maze)
+
+	return maze
+}
+
+// GenerateWithOpenness creates a maze whose loopiness is controlled by a single knob.
+// An openness of 0 yields a perfect maze, while 1 opens an extra wall at every
+// dead end to produce a heavily braided maze with many loops. Values outside
+// [0, 1] are clamped.
+func (g *Generator) GenerateWithOpenness(width, height int, openness float64) *Maze {
 	maze := g.Generate(width, height)
+	g.Braid(maze, openness)
+	return maze
+}
+
+// Braid adds loops to the maze by opening one interior wall on a fraction of its
+// dead-end cells. A density of 0 leaves the maze untouched and 1 removes every
+// dead end. Values outside [0, 1] are clamped. Outer boundary walls are never
+// removed, and since walls are only removed the maze stays solvable.
+func (g *Generator) Braid(maze *Maze, density float64) {
+	density = min(max(density, 0), 1)
+
+	// Collect the dead ends present before any walls are removed
+	var deadEnds []*Cell
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+			if openDirections(maze, cell) == 1 {
+				deadEnds = append(deadEnds, cell)
+			}
+		}
+	}
+	g.shuffleNeighbors(deadEnds)
+
+	count := int(density*float64(len(deadEnds)) + 0.5)
+	for _, cell := range deadEnds[:count] {
+		// An earlier removal may already have opened this cell up
+		if openDirections(maze, cell) != 1 {
+			continue
+		}
+
+		g.openDeadEnd(maze, cell)
+	}
+}
+
+// openDeadEnd removes the wall between a dead-end cell and a random walled
+// neighbor inside the maze, turning the dead end into part of a loop
+func (g *Generator) openDeadEnd(maze *Maze, cell *Cell) {
+	var candidates []*Cell
+	for _, dir := range AllDirections() {
+		neighbor := maze.GetNeighbor(cell, dir)
+		if neighbor != nil && cell.HasWall(dir) {
+			candidates = append(candidates, neighbor)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	maze.RemoveWall(cell, candidates[g.rng.Intn(len(candidates))])
+}
+
+// GenerateDungeon creates a dungeon-style map of open rectangular rooms joined
+// by winding corridors. Up to roomCount rooms from 2x2 cells to a third of the
+// maze in each dimension are placed at random, at least one cell apart so they
+// never overlap or touch; fewer are placed if they don't fit. Every wall inside
+// a room is removed, the cells between rooms are carved with recursive
+// backtracking, and each room gets one door into a neighboring corridor. Any
+// region still cut off is then joined up, so every room is reachable. The start
+// is placed in the first room and the finish at the cell farthest from it.
+func (g *Generator) GenerateDungeon(width, height, roomCount int) *Maze {
+	maze := NewMaze(width, height)
+
+	var rooms []image.Rectangle
+	for attempt := 0; attempt < roomCount*20 && len(rooms) < roomCount; attempt++ {
+		roomWidth := min(2+g.rng.Intn(max(width/3-1, 1)), width)
+		roomHeight := min(2+g.rng.Intn(max(height/3-1, 1)), height)
+		x := g.rng.Intn(width - roomWidth + 1)
+		y := g.rng.Intn(height - roomHeight + 1)
+		room := image.Rect(x, y, x+roomWidth, y+roomHeight)
+
+		// Keep a one-cell gap between rooms
+		overlaps := false
+		for _, other := range rooms {
+			if room.Inset(-1).Overlaps(other) {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			rooms = append(rooms, room)
+		}
+	}
+
+	// Open up each room and mark it visited so corridors go around it
+	for _, room := range rooms {
+		for y := room.Min.Y; y < room.Max.Y; y++ {
+			for x := room.Min.X; x < room.Max.X; x++ {
+				cell := maze.GetCell(x, y)
+				cell.Visited = true
+				if x+1 < room.Max.X {
+					maze.RemoveWall(cell, maze.GetCell(x+1, y))
+				}
+				if y+1 < room.Max.Y {
+					maze.RemoveWall(cell, maze.GetCell(x, y+1))
+				}
+			}
+		}
+	}
+
+	// Carve corridors through the remaining cells, including any pockets the rooms enclose
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if cell := maze.GetCell(x, y); !cell.Visited {
+				g.backtrack(maze, cell, nil)
+			}
+		}
+	}
+
+	// Give each room a door to a random corridor cell along its edge
+	for _, room := range rooms {
+		type door struct{ inside, outside *Cell }
+		var doors []door
+		for y := room.Min.Y; y < room.Max.Y; y++ {
+			for x := room.Min.X; x < room.Max.X; x++ {
+				cell := maze.GetCell(x, y)
+				for _, dir := range AllDirections() {
+					neighbor := maze.GetNeighbor(cell, dir)
+					if neighbor != nil && !image.Pt(neighbor.X, neighbor.Y).In(room) {
+						doors = append(doors, door{cell, neighbor})
+					}
+				}
+			}
+		}
+		if len(doors) > 0 {
+			d := doors[g.rng.Intn(len(doors))]
+			maze.RemoveWall(d.inside, d.outside)
+		}
+	}
+
+	NewValidator().RepairConnectivity(maze)
+
+	if len(rooms) > 0 {
+		maze.Start = Point{rooms[0].Min.X, rooms[0].Min.Y}
+	}
+	maze.Finish = farthestPoint(NewValidator().bfsDistances(maze, maze.Start))
+	return maze
+}
+
+// EnsureUniqueSolution closes walls until exactly one shortest path leads from
+// start to finish, such as after Braid has added loops. The path returned by
+// FindPath is kept, and every other passage that enters one of its cells along
+// some shortest route is walled off. Each closed passage leads to a cell that
+// still reaches the start by another route, so no cell is cut off. It returns
+// the number of walls closed, which is 0 if the solution was already unique
+// or the maze has no solution.
+func (g *Generator) EnsureUniqueSolution(maze *Maze) int {
+	validator := NewValidator()
+	if validator.ShortestPathCount(maze) <= 1 {
+		return 0
+	}
+
+	path := validator.FindPath(maze)
+	fromStart := validator.bfsDistances(maze, maze.Start)
+
+	closed := 0
+	for i := 1; i < len(path); i++ {
+		cell := maze.GetCell(path[i].X, path[i].Y)
+		for _, dir := range maze.directions() {
+			neighbor := maze.GetNeighbor(cell, dir)
+			if neighbor == nil || !maze.CanMove(neighbor, cell) {
+				continue
+			}
+
+			// Any other neighbor one step nearer the start offers an equally short route
+			p := Point{neighbor.X, neighbor.Y}
+			if d, ok := fromStart[p]; !ok || d != i-1 || p == path[i-1] {
+				continue
+			}
+
+			addWall(maze, cell, dir)
+			closed++
+		}
+	}
+
+	return closed
+}
+
+// GenerateKruskal creates a new maze using randomized Kruskal's algorithm.
+// Every interior wall is considered once in random order and removed whenever
+// the cells on either side are not yet connected.
+func (g *Generator) GenerateKruskal(width, height int) *Maze {
+	maze := NewMaze(width, height)
+
+	// Build the list of interior walls as east and south edges
+	type edge struct {
+		from, to *Cell
+	}
+	var edges []edge
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cell := maze.GetCell(x, y)
+			cell.Visited = true
+			for _, dir := range []Direction{East, South} {
+				if neighbor := maze.GetNeighbor(cell, dir); neighbor != nil {
+					edges = append(edges, edge{cell, neighbor})
+				}
+			}
+		}
+	}
+
+	// Shuffle the edges for randomness
+	for i := len(edges) - 1; i > 0; i-- {
+		j := g.rng.Intn(i + 1)
+		edges[i], edges[j] = edges[j], edges[i]
+	}
+
+	sets := newDisjointSet(width * height)
+	for _, e := range edges {
+		if sets.union(e.from.Y*width+e.from.X, e.to.Y*width+e.to.X) {
+			maze.RemoveWall(e.from, e.to)
+		}
+	}
+
+	return maze
+}
+
+// disjointSet is a union-find structure over integer indices
+type disjointSet struct {
+	parent []int
+	rank   []int
+}
+
+// newDisjointSet creates a disjoint set where every index starts in its own set
+func newDisjointSet(size int) *disjointSet {
+	parent := make([]int, size)
+	for i := range parent {
+		parent[i] = i
+	}
+	return &disjointSet{
+		parent: parent,
+		rank:   make([]int, size),
+	}
+}
+
+// find returns the representative of the set containing i
+func (d *disjointSet) find(i int) int {
+	for d.parent[i] != i {
+		// Path halving keeps the trees shallow
+		d.parent[i] = d.parent[d.parent[i]]
+		i = d.parent[i]
+	}
+	return i
+}
+
+// union merges the sets containing a and b, returning false if they were already joined
+func (d *disjointSet) union(a, b int) bool {
+	rootA, rootB := d.find(a), d.find(b)
+	if rootA == rootB {
+		return false
+	}
+
+	// Attach the shorter tree beneath the taller one
+	switch {
+	case d.rank[rootA] < d.rank[rootB]:
+		d.parent[rootA] = rootB
+	case d.rank[rootA] > d.rank[rootB]:
+		d.parent[rootB] = rootA
+	default:
+		d.parent[rootB] = rootA
+		d.rank[rootA]++
+	}
+	return true
+}
+
+// GenerateWith creates a new maze using the selected algorithm.
+// Unknown algorithms fall back to recursive backtracking.
+func (g *Generator) GenerateWith(algo GenerationAlgorithm, width, height int) *Maze {
+	switch algo {
+	case Prim:
+		return g.GeneratePrim(width, height)
+	case Kruskal:
+		return g.GenerateKruskal(width, height)
+	case Wilson:
+		return g.GenerateWilson(width, height)
+	case RecursiveDivision:
+		return g.GenerateRecursiveDivision(width, height)
+	case BinaryTree:
+		return g.GenerateBinaryTree(width, height)
+	case Sidewinder:
+		return g.GenerateSidewinder(width, height)
+	case AldousBroder:
+		return g.GenerateAldousBroder(width, height)
+	case HuntAndKill:
+		return g.GenerateHuntAndKill(width, height)
+	default:
+		return g.Generate(width, height)
+	}
+}
+
+// GeneratePrim creates a new maze using randomized Prim's algorithm.
+// The maze grows outward from a random cell by repeatedly connecting a random
+// frontier cell to one of its already-visited neighbors.
+func (g *Generator) GeneratePrim(width, height int) *Maze {
+	maze := NewMaze(width, height)
+
+	// Start from a random cell
+	start := maze.GetCell(g.rng.Intn(width), g.rng.Intn(height))
+	start.Visited = true
+
+	inFrontier := make(map[*Cell]bool)
+	frontier := g.getUnvisitedNeighbors(maze, start)
+	for _, cell := range frontier {
+		inFrontier[cell] = true
+	}
+
+	for len(frontier) > 0 {
+		// Remove a random cell from the frontier
+		i := g.rng.Intn(len(frontier))
+		cell := frontier[i]
+		frontier[i] = frontier[len(frontier)-1]
+		frontier = frontier[:len(frontier)-1]
+
+		// Connect it to a random visited neighbor
+		var visited []*Cell
+		for _, dir := range AllDirections() {
+			neighbor := maze.GetNeighbor(cell, dir)
+			if neighbor != nil && neighbor.Visited {
+				visited = append(visited, neighbor)
+			}
+		}
+		maze.RemoveWall(cell, visited[g.rng.Intn(len(visited))])
+		cell.Visited = true
+
+		// Grow the frontier with the new cell's unvisited neighbors
+		for _, neighbor := range g.getUnvisitedNeighbors(maze, cell) {
+			if !inFrontier[neighbor] {
+				inFrontier[neighbor] = true
+				frontier = append(frontier, neighbor)
+			}
+		}
+	}
+
+	return maze
+}
+
+// PlaceStartAndFinishFarthest places start and finish at the two cells with the
+// longest shortest path between them. A BFS from an arbitrary cell finds the most
+// distant cell, and a second BFS from there finds the other end of the diameter.
+// For perfect mazes this is exact; for mazes with loops it is a close approximation.
+// Any multi-goal Finishes are cleared.
+func (g *Generator) PlaceStartAndFinishFarthest(maze *Maze) {
+	validator := NewValidator()
+
+	start := farthestPoint(validator.bfsDistances(maze, Point{0, 0}))
+	finish := farthestPoint(validator.bfsDistances(maze, start))
+
+	maze.Start = start
+	maze.SetFinish(finish)
+}
+
+// farthestPoint returns the point with the greatest distance, preferring the
+// first in row-major order on ties so the result is deterministic
+func farthestPoint(distances map[Point]int) Point {
+	var best Point
+	bestDistance := -1
+	for p, d := range distances {
+		if d > bestDistance || (d == bestDistance && (p.Y < best.Y || (p.Y == best.Y && p.X < best.X))) {
+			best = p
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// SetEntrance places the start on the given outer edge at the given offset and
+// opens the outer wall there. The offset is measured along the edge: the column
+// for North and South edges, the row for East and West edges.
+func (g *Generator) SetEntrance(maze *Maze, edge Direction, offset int) error {
+	pos, err := g.openEdge(maze, edge, offset)
+	if err != nil {
+		return err
+	}
+	maze.Start = pos
+	return nil
+}
+
+// SetExit places the finish on the given outer edge at the given offset and
+// opens the outer wall there, using the same offset rules as SetEntrance. Any
+// multi-goal Finishes are cleared.
+func (g *Generator) SetExit(maze *Maze, edge Direction, offset int) error {
+	pos, err := g.openEdge(maze, edge, offset)
+	if err != nil {
+		return err
+	}
+	maze.SetFinish(pos)
+	return nil
+}
+
+// openEdge removes the outer wall of the cell at the given offset along an edge
+// and returns that cell's position
+func (g *Generator) openEdge(maze *Maze, edge Direction, offset int) (Point, error) {
+	var pos Point
+	var length int
+
+	switch edge {
+	case North:
+		pos, length = Point{offset, 0}, maze.Width
+	case South:
+		pos, length = Point{offset, maze.Height - 1}, maze.Width
+	case West:
+		pos, length = Point{0, offset}, maze.Height
+	case East:
+		pos, length = Point{maze.Width - 1, offset}, maze.Height
+	default:
+		return Point{}, fmt.Errorf("invalid edge %v", edge)
+	}
+
+	if offset < 0 || offset >= length {
+		return Point{}, fmt.Errorf("offset %d out of range for %v edge of length %d", offset, edge, length)
+	}
+
+	maze.GetCell(pos.X, pos.Y).SetWall(edge, false)
+	return pos, nil
+}
+
+// GenerateWilson creates a new maze using Wilson's algorithm.
+// Loop-erased random walks are grown from each cell until they hit the maze,
+// which yields a uniformly random spanning tree: every possible perfect maze is
+// equally likely, unlike backtracking or Prim which bias the corridor texture.
+func (g *Generator) GenerateWilson(width, height int) *Maze {
+	maze := NewMaze(width, height)
+
+	// Seed the tree with one random cell
+	maze.GetCell(g.rng.Intn(width), g.rng.Intn(height)).Visited = true
+
+	// Remember the last direction taken out of each cell during a walk.
+	// Overwriting it when the walk revisits a cell erases the loop.
+	next := make(map[*Cell]*Cell)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			start := maze.GetCell(x, y)
+			if start.Visited {
+				continue
+			}
+
+			// Random walk until the walk reaches a cell already in the tree
+			current := start
+			for !current.Visited {
+				neighbor := g.randomNeighbor(maze, current)
+				next[current] = neighbor
+				current = neighbor
+			}
+
+			// Carve the loop-erased path into the tree
+			for current = start; !current.Visited; current = next[current] {
+				current.Visited = true
+				maze.RemoveWall(current, next[current])
+			}
+		}
+	}
+
+	return maze
+}
+
+// GenerateAldousBroder creates a new maze using the Aldous-Broder algorithm.
+// A single random walk wanders the grid and carves into every cell the first
+// time it arrives. Like Wilson's algorithm the result is a uniformly random
+// spanning tree, but the walk wastes most of its steps revisiting cells, so it
+// is much slower on large mazes.
+func (g *Generator) GenerateAldousBroder(width, height int) *Maze {
+	maze := NewMaze(width, height)
+
+	current := maze.GetCell(g.rng.Intn(width), g.rng.Intn(height))
+	current.Visited = true
+	remaining := width*height - 1
+
+	for remaining > 0 {
+		neighbor := g.randomNeighbor(maze, current)
+		if !neighbor.Visited {
+			maze.RemoveWall(current, neighbor)
+			neighbor.Visited = true
+			remaining--
+		}
+		current = neighbor
+	}
+
+	return maze
+}
+
+// randomNeighbor returns a random in-bounds neighbor of the cell
+func (g *Generator) randomNeighbor(maze *Maze, cell *Cell) *Cell {
+	var neighbors []*Cell
+	for _, dir := range AllDirections() {
+		if neighbor := maze.GetNeighbor(cell, dir); neighbor != nil {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors[g.rng.Intn(len(neighbors))]
+}
+
+// GenerateRecursiveDivision creates a new maze using recursive division.
+// Unlike the carving algorithms this one builds walls: it starts from an open
+// field with only the outer boundary, then splits each chamber with a straight
+// wall containing a single passage until every chamber is one cell wide.
+// Each wall joins two disconnected regions through exactly one gap, so the
+// result is still a perfect maze.
+func (g *Generator) GenerateRecursiveDivision(width, height int) *Maze {
+	maze := NewMaze(width, height)
+
+	// Remove every interior wall, leaving only the boundary
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cell := maze.GetCell(x, y)
+			cell.Visited = true
+			for _, dir := range []Direction{East, South} {
+				if neighbor := maze.GetNeighbor(cell, dir); neighbor != nil {
+					maze.RemoveWall(cell, neighbor)
+				}
+			}
+		}
+	}
+
+	g.divide(maze, 0, 0, width, height)
+	return maze
+}
+
+// divide splits the chamber at (x, y) of size w by h with one wall and recurses into both halves
+func (g *Generator) divide(maze *Maze, x, y, w, h int) {
+	if w < 2 || h < 2 {
+		return
+	}
+
+	// Cut across the longer side so chambers stay roughly square
+	horizontal := h > w
+	if w == h {
+		horizontal = g.rng.Intn(2) == 0
+	}
+
+	if horizontal {
+		// Wall along the south side of row wallY, with one passage column
+		wallY := y + g.rng.Intn(h-1)
+		passage := x + g.rng.Intn(w)
+		for i := x; i < x+w; i++ {
+			if i != passage {
+				addWall(maze, maze.GetCell(i, wallY), South)
+			}
+		}
+		g.divide(maze, x, y, w, wallY-y+1)
+		g.divide(maze, x, wallY+1, w, y+h-wallY-1)
+		return
+	}
+
+	// Wall along the east side of column wallX, with one passage row
+	wallX := x + g.rng.Intn(w-1)
+	passage := y + g.rng.Intn(h)
+	for i := y; i < y+h; i++ {
+		if i != passage {
+			addWall(maze, maze.GetCell(wallX, i), East)
+		}
+	}
+	g.divide(maze, x, y, wallX-x+1, h)
+	g.divide(maze, wallX+1, y, x+w-wallX-1, h)
+}
+
+// addWall restores the wall on the given side of a cell and the matching side of its neighbor
+func addWall(maze *Maze, cell *Cell, dir Direction) {
+	cell.SetWall(dir, true)
+	if neighbor := maze.GetNeighbor(cell, dir); neighbor != nil {
+		neighbor.SetWall(dir.Opposite(), true)
+	}
+}
+
+// GenerateBinaryTree creates a new maze using the binary tree algorithm.
+// Each cell independently carves either north or east, which makes it O(n)
+// and memory-free but heavily biased: the top row and right column are always
+// unbroken corridors and passages trend diagonally toward the north-east corner.
+func (g *Generator) GenerateBinaryTree(width, height int) *Maze {
+	maze := NewMaze(width, height)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cell := maze.GetCell(x, y)
+			cell.Visited = true
+
+			var candidates []*Cell
+			for _, dir := range []Direction{North, East} {
+				if neighbor := maze.GetNeighbor(cell, dir); neighbor != nil {
+					candidates = append(candidates, neighbor)
+				}
+			}
+			// The north-east corner has nowhere to carve
+			if len(candidates) > 0 {
+				maze.RemoveWall(cell, candidates[g.rng.Intn(len(candidates))])
+			}
+		}
+	}
+
+	return maze
+}
+
+// GenerateSidewinder creates a new maze using the sidewinder algorithm.
+// Each row is split into eastward runs, and every run is joined to the row
+// above through one random cell. It is O(n) and only needs the current run in
+// memory, but the top row is always a single corridor and every cell can reach
+// it without ever moving south, so vertical passages have a noticeable upward bias.
+func (g *Generator) GenerateSidewinder(width, height int) *Maze {
+	maze := NewMaze(width, height)
+
+	for y := 0; y < height; y++ {
+		var run []*Cell
+		for x := 0; x < width; x++ {
+			cell := maze.GetCell(x, y)
+			cell.Visited = true
+			run = append(run, cell)
+
+			atEastEdge := x == width-1
+			atNorthEdge := y == 0
+
+			// Close the run at the east edge, or at random below the top row
+			if atEastEdge || (!atNorthEdge && g.rng.Intn(2) == 0) {
+				if !atNorthEdge {
+					member := run[g.rng.Intn(len(run))]
+					maze.RemoveWall(member, maze.GetNeighbor(member, North))
+				}
+				run = run[:0]
+				continue
+			}
+
+			maze.RemoveWall(cell, maze.GetNeighbor(cell, East))
+		}
+	}
+
+	return maze
+}
+
+// GenerateHuntAndKill creates a new maze using the hunt-and-kill algorithm.
+// A random walk carves into unvisited neighbors until it gets stuck, then the
+// grid is scanned row by row for the first unvisited cell next to a visited
+// one, which is joined to the maze and becomes the start of the next walk.
+// The corridors are long and winding like recursive backtracking, but no
+// stack is kept, at the cost of rescanning the grid after each walk.
+func (g *Generator) GenerateHuntAndKill(width, height int) *Maze {
+	maze := NewMaze(width, height)
+
+	current := maze.GetCell(g.rng.Intn(width), g.rng.Intn(height))
+	current.Visited = true
+
+	for current != nil {
+		// Kill: walk until there's nowhere new to go
+		if neighbors := g.getUnvisitedNeighbors(maze, current); len(neighbors) > 0 {
+			next := neighbors[g.rng.Intn(len(neighbors))]
+			maze.RemoveWall(current, next)
+			next.Visited = true
+			current = next
+			continue
+		}
+
+		current = g.hunt(maze)
+	}
+
+	return maze
+}
+
+// hunt finds the first unvisited cell bordering the visited region, connects it
+// to a random visited neighbor, and returns it; it returns nil once every cell is visited
+func (g *Generator) hunt(maze *Maze) *Cell {
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+			if cell.Visited {
+				continue
+			}
+
+			var visited []*Cell
+			for _, dir := range AllDirections() {
+				if neighbor := maze.GetNeighbor(cell, dir); neighbor != nil && neighbor.Visited {
+					visited = append(visited, neighbor)
+				}
+			}
+			if len(visited) == 0 {
+				continue
+			}
+
+			maze.RemoveWall(cell, visited[g.rng.Intn(len(visited))])
+			cell.Visited = true
+			return cell
+		}
+	}
+	return nil
+}
+
+// GenerateGrowingTree creates a new maze using the growing-tree algorithm.
+// Cells are kept in an active list: each step extends the cell chosen by
+// policy into a random unvisited neighbor, adding that neighbor to the list,
+// and drops the cell once it has none left. GrowingTreeNewest reproduces the
+// long winding corridors of recursive backtracking, GrowingTreeRandom the short
+// branches of Prim's algorithm, and GrowingTreeOldest long straight passages
+// that make for a very easy maze.
+func (g *Generator) GenerateGrowingTree(width, height int, policy SelectionPolicy) *Maze {
+	maze := NewMaze(width, height)
+
+	start := maze.GetCell(g.rng.Intn(width), g.rng.Intn(height))
+	start.Visited = true
+	active := []*Cell{start}
+
+	for len(active) > 0 {
+		var i int
+		switch policy {
+		case GrowingTreeRandom:
+			i = g.rng.Intn(len(active))
+		case GrowingTreeOldest:
+			i = 0
+		default:
+			i = len(active) - 1
+		}
+		cell := active[i]
+
+		neighbors := g.getUnvisitedNeighbors(maze, cell)
+		if len(neighbors) == 0 {
+			// Keep the order intact so GrowingTreeNewest and GrowingTreeOldest stay meaningful
+			active = append(active[:i], active[i+1:]...)
+			continue
+		}
+
+		next := neighbors[g.rng.Intn(len(neighbors))]
+		maze.RemoveWall(cell, next)
+		next.Visited = true
+		active = append(active, next)
+	}
+
+	return maze
+}
+
+// GenerateDiagonal creates a new diagonal maze using recursive backtracking
+// over all eight directions. A diagonal passage is only carved when the
+// passage crossing it through the same corner is still closed, so corridors
+// never cross. The start and finish are placed like PlaceStartAndFinish.
+func (g *Generator) GenerateDiagonal(width, height int) *Maze {
+	maze := NewDiagonalMaze(width, height)
+
+	start := maze.GetCell(g.rng.Intn(width), g.rng.Intn(height))
+	start.Visited = true
+	stack := []*Cell{start}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+
+		var neighbors []*Cell
+		for _, dir := range EightDirections() {
+			neighbor := maze.GetNeighbor(current, dir)
+			if neighbor == nil || neighbor.Visited {
+				continue
+			}
+			if dir.IsDiagonal() {
+				if crossing, crossingDir := maze.crossingPassage(current, dir); !crossing.HasWall(crossingDir) {
+					continue
+				}
+			}
+			neighbors = append(neighbors, neighbor)
+		}
+
+		if len(neighbors) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := neighbors[g.rng.Intn(len(neighbors))]
+		maze.RemoveWall(current, next)
+		next.Visited = true
+		stack = append(stack, next)
+	}
+
 	g.PlaceStartAndFinish(maze)
 	return maze
 }
+
+// defaultRetries is the number of generation attempts made by GenerateWithContext
+const defaultRetries = 5
+
+// contextCheckInterval is how many carving steps run between cancellation checks
+const contextCheckInterval = 1024
+
+// GenerateWithContext generates a validated maze like GenerateWithValidation,
+// but stops early and returns the context's error if ctx is cancelled or its
+// deadline passes. The context is checked periodically while carving and
+// before each start/finish placement attempt. Given the same seed, an
+// uncancelled call produces the same maze as GenerateWithValidation with
+// five retries, and fails with the same errors.
+func (g *Generator) GenerateWithContext(ctx context.Context, width, height int) (*Maze, error) {
+	if err := checkDimensions(width, height); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < defaultRetries; attempt++ {
+		maze, err := g.generateWithContext(ctx, width, height)
+		if err != nil {
+			return nil, err
+		}
+
+		// Try multiple start/finish placements
+		for placementAttempt := 0; placementAttempt < 10; placementAttempt++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			g.PlaceStartAndFinish(maze)
+
+			// Validate that a path exists
+			validator := NewValidator()
+			if validator.HasPath(maze) {
+				return maze, nil
+			}
+		}
+	}
+
+	return nil, ErrNoValidPath
+}
+
+// generateWithContext carves a maze with the same choices as Generate,
+// checking the context for cancellation as it goes
+func (g *Generator) generateWithContext(ctx context.Context, width, height int) (*Maze, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	stepper := NewStepGenerator(g)
+	stepper.Init(width, height)
+
+	for steps := 1; ; steps++ {
+		if carved, _, _ := stepper.Step(); !carved {
+			break
+		}
+		if steps%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return stepper.Maze(), nil
+}
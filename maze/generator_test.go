@@ -0,0 +1,259 @@
+package maze
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// testSeeds are the fixed seeds the generator tests run with
+var testSeeds = []int64{1, 2, 42, 1234}
+
+// countPassages returns the number of removed interior walls, counting each
+// shared wall once through its east or south side
+func countPassages(m *Maze) int {
+	passages := 0
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			cell := m.GetCell(x, y)
+			for _, dir := range []Direction{East, South} {
+				if m.GetNeighbor(cell, dir) != nil && !cell.HasWall(dir) {
+					passages++
+				}
+			}
+		}
+	}
+	return passages
+}
+
+func TestGenerateKruskalRemovesSpanningTreeWalls(t *testing.T) {
+	for _, seed := range testSeeds {
+		m := NewGeneratorWithSeed(seed).GenerateKruskal(12, 9)
+		if got, want := countPassages(m), 12*9-1; got != want {
+			t.Errorf("seed %d: removed %d walls, want %d", seed, got, want)
+		}
+	}
+}
+
+// checkPerfect fails the test unless m is a connected perfect maze
+func checkPerfect(t *testing.T, name string, seed int64, m *Maze) {
+	t.Helper()
+	if !NewValidator().IsPerfect(m) {
+		t.Errorf("%s seed %d: maze is not a connected perfect maze", name, seed)
+	}
+}
+
+func TestGenerateWilsonIsConnectedSpanningTree(t *testing.T) {
+	for _, seed := range testSeeds {
+		m := NewGeneratorWithSeed(seed).GenerateWilson(12, 9)
+		checkPerfect(t, "GenerateWilson", seed, m)
+		if got, want := countPassages(m), 12*9-1; got != want {
+			t.Errorf("seed %d: carved %d walls, want %d", seed, got, want)
+		}
+	}
+}
+
+func TestGenerateBinaryTreeAndSidewinderAreConnected(t *testing.T) {
+	for _, seed := range testSeeds {
+		g := NewGeneratorWithSeed(seed)
+		checkPerfect(t, "GenerateBinaryTree", seed, g.GenerateBinaryTree(12, 9))
+		checkPerfect(t, "GenerateSidewinder", seed, g.GenerateSidewinder(12, 9))
+	}
+}
+
+func TestGenerateAldousBroderIsConnected(t *testing.T) {
+	for _, seed := range testSeeds {
+		m := NewGeneratorWithSeed(seed).GenerateAldousBroder(12, 9)
+		checkPerfect(t, "GenerateAldousBroder", seed, m)
+	}
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	g := NewGeneratorWithSeed(1)
+	b.ReportAllocs()
+	for b.Loop() {
+		g.Generate(20, 20)
+	}
+}
+
+func BenchmarkGenerateInto(b *testing.B) {
+	g := NewGeneratorWithSeed(1)
+	m := NewMaze(20, 20)
+	b.ReportAllocs()
+	for b.Loop() {
+		g.GenerateInto(m)
+	}
+}
+
+func TestGenerateHuntAndKillIsPerfect(t *testing.T) {
+	for _, seed := range testSeeds {
+		m := NewGeneratorWithSeed(seed).GenerateHuntAndKill(12, 9)
+		checkPerfect(t, "GenerateHuntAndKill", seed, m)
+		if got, want := countPassages(m), 12*9-1; got != want {
+			t.Errorf("seed %d: carved %d walls, want %d", seed, got, want)
+		}
+	}
+}
+
+func TestGenerateGrowingTreeIsPerfectForEachPolicy(t *testing.T) {
+	policies := []SelectionPolicy{GrowingTreeNewest, GrowingTreeRandom, GrowingTreeOldest}
+	for _, policy := range policies {
+		for _, seed := range testSeeds {
+			m := NewGeneratorWithSeed(seed).GenerateGrowingTree(12, 9, policy)
+			checkPerfect(t, fmt.Sprintf("GenerateGrowingTree(policy %d)", policy), seed, m)
+		}
+	}
+}
+
+func TestEnsureUniqueSolutionOnBraidedMazes(t *testing.T) {
+	v := NewValidator()
+	tested := 0
+	for seed := int64(1); seed <= 40; seed++ {
+		g := NewGeneratorWithSeed(seed)
+		m := testMaze(t, seed, 12, 12)
+		g.Braid(m, 1)
+		if v.ShortestPathCount(m) < 2 {
+			continue
+		}
+		tested++
+
+		length, regions := len(v.FindPath(m)), v.RegionCount(m)
+		if closed := g.EnsureUniqueSolution(m); closed == 0 {
+			t.Errorf("seed %d: closed no walls despite several shortest paths", seed)
+		}
+		if n := v.ShortestPathCount(m); n != 1 {
+			t.Errorf("seed %d: ShortestPathCount = %d, want 1", seed, n)
+		}
+		if n := len(v.FindPath(m)); n != length {
+			t.Errorf("seed %d: FindPath length changed from %d to %d", seed, length, n)
+		}
+		if n := v.RegionCount(m); n != regions {
+			t.Errorf("seed %d: RegionCount changed from %d to %d", seed, regions, n)
+		}
+	}
+	if tested == 0 {
+		t.Fatal("no braided maze had more than one shortest path")
+	}
+}
+
+func TestFinishSettersClearFinishes(t *testing.T) {
+	g := NewGeneratorWithSeed(1)
+	m := g.Generate(10, 10)
+	goals := []Point{{9, 9}, {0, 9}}
+
+	m.SetFinishes(goals)
+	g.PlaceStartAndFinish(m)
+	if m.Finishes != nil {
+		t.Errorf("PlaceStartAndFinish left Finishes %v", m.Finishes)
+	}
+
+	m.SetFinishes(goals)
+	g.PlaceStartAndFinishFarthest(m)
+	if m.Finishes != nil {
+		t.Errorf("PlaceStartAndFinishFarthest left Finishes %v", m.Finishes)
+	}
+
+	m.SetFinishes(goals)
+	if err := g.SetExit(m, East, 3); err != nil {
+		t.Fatal(err)
+	}
+	if all := m.AllFinishes(); len(all) != 1 || all[0] != (Point{9, 3}) {
+		t.Errorf("after SetExit AllFinishes = %v, want [{9 3}]", all)
+	}
+}
+
+func TestGenerateMaskedWithFewerThanTwoCells(t *testing.T) {
+	g := NewGeneratorWithSeed(1)
+
+	m := g.GenerateMasked([][]bool{{false, false}, {false, true}})
+	if want := (Point{1, 1}); m.Start != want || m.Finish != want {
+		t.Errorf("one enabled cell: start %v, finish %v, want both %v", m.Start, m.Finish, want)
+	}
+
+	m = g.GenerateMasked([][]bool{{false, false}, {false, false}})
+	if m.Start != (Point{}) || m.Finish != (Point{}) {
+		t.Errorf("no enabled cells: start %v, finish %v, want both at the origin", m.Start, m.Finish)
+	}
+}
+
+func TestSeedReproducesFirstMaze(t *testing.T) {
+	g := NewGenerator()
+	first := g.Generate(10, 8)
+	second := g.Generate(10, 8)
+
+	replay := NewGeneratorWithSeed(g.Seed())
+	if !first.Equal(replay.Generate(10, 8)) {
+		t.Error("a generator with Seed() did not reproduce the first maze")
+	}
+	if !second.Equal(replay.Generate(10, 8)) {
+		t.Error("repeating the same calls did not reproduce the second maze")
+	}
+}
+
+func TestGenerateFromOrigin(t *testing.T) {
+	g := NewGeneratorWithSeed(1)
+	m, err := g.GenerateFrom(12, 9, 11, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPerfect(t, "GenerateFrom", 1, m)
+
+	for _, origin := range []Point{{-1, 0}, {12, 0}, {0, 9}} {
+		if _, err := g.GenerateFrom(12, 9, origin.X, origin.Y); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("origin %v: error %v, want ErrOutOfRange", origin, err)
+		}
+	}
+	if _, err := g.GenerateFrom(0, 9, 0, 0); !errors.Is(err, ErrInvalidDimensions) {
+		t.Errorf("0x9 maze: error %v, want ErrInvalidDimensions", err)
+	}
+}
+
+func TestGenerateWideCorridorsRounding(t *testing.T) {
+	g := NewGeneratorWithSeed(1)
+	tests := []struct{ width, height, wantWidth, wantHeight int }{
+		{10, 8, 10, 8},
+		{11, 9, 10, 8},
+		{1, 1, 2, 2},
+		{1, 7, 2, 6},
+	}
+	for _, tt := range tests {
+		m := g.GenerateWideCorridors(tt.width, tt.height)
+		if m.Width != tt.wantWidth || m.Height != tt.wantHeight {
+			t.Errorf("GenerateWideCorridors(%d, %d) is %dx%d, want %dx%d",
+				tt.width, tt.height, m.Width, m.Height, tt.wantWidth, tt.wantHeight)
+		}
+	}
+}
+
+func TestGenerateIntoClearsMask(t *testing.T) {
+	m := NewGeneratorWithSeed(1).GenerateMasked(ringMask(7))
+	NewGeneratorWithSeed(2).GenerateInto(m)
+	if want := NewGeneratorWithSeed(2).Generate(7, 7); !m.Equal(want) {
+		t.Error("GenerateInto on a masked maze differs from Generate")
+	}
+}
+
+func TestGenerateIntoResetsDiagonalMaze(t *testing.T) {
+	m := NewGeneratorWithSeed(1).GenerateDiagonal(8, 6)
+	NewGeneratorWithSeed(2).GenerateInto(m)
+	if want := NewGeneratorWithSeed(2).Generate(8, 6); !m.Equal(want) {
+		t.Error("GenerateInto on a diagonal maze differs from Generate")
+	}
+	checkPerfect(t, "GenerateInto", 2, m)
+}
+
+func TestBraidIgnoresDisableShuffle(t *testing.T) {
+	for _, seed := range testSeeds {
+		m := testMaze(t, seed, 12, 12)
+		shuffled, fixed := m.Clone(), m.Clone()
+
+		NewGeneratorWithSeed(seed).Braid(shuffled, 0.5)
+		g := NewGeneratorWithSeed(seed)
+		g.DisableShuffle = true
+		g.Braid(fixed, 0.5)
+
+		if !shuffled.Equal(fixed) {
+			t.Errorf("seed %d: DisableShuffle changed which dead ends Braid opened", seed)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package maze
+
+import (
+	"image"
+	"math"
+)
+
+// HexDirection represents the six directions of a hexagonal grid
+type HexDirection int
+
+const (
+	HexNorthEast HexDirection = iota
+	HexEast
+	HexSouthEast
+	HexSouthWest
+	HexWest
+	HexNorthWest
+)
+
+// hexDirections lists the six directions in clockwise order starting from north-east
+var hexDirections = []HexDirection{HexNorthEast, HexEast, HexSouthEast, HexSouthWest, HexWest, HexNorthWest}
+
+// Opposite returns the direction pointing the other way
+func (d HexDirection) Opposite() HexDirection {
+	return (d + 3) % 6
+}
+
+// HexCell represents a single cell in a hexagonal maze
+type HexCell struct {
+	X, Y    int
+	Visited bool
+	Walls   map[HexDirection]bool
+}
+
+// NewHexCell creates a new hex cell with all six walls intact
+func NewHexCell(x, y int) *HexCell {
+	walls := make(map[HexDirection]bool, len(hexDirections))
+	for _, dir := range hexDirections {
+		walls[dir] = true
+	}
+	return &HexCell{
+		X:     x,
+		Y:     y,
+		Walls: walls,
+	}
+}
+
+// HexMaze represents a maze on a grid of pointy-topped hexagons.
+// Odd rows are shifted half a cell to the right.
+type HexMaze struct {
+	Width, Height int
+	Cells         [][]*HexCell
+	Start, Finish Point
+}
+
+// NewHexMaze creates a new hex maze with the specified dimensions
+func NewHexMaze(width, height int) *HexMaze {
+	cells := make([][]*HexCell, height)
+	for y := 0; y < height; y++ {
+		cells[y] = make([]*HexCell, width)
+		for x := 0; x < width; x++ {
+			cells[y][x] = NewHexCell(x, y)
+		}
+	}
+
+	return &HexMaze{
+		Width:  width,
+		Height: height,
+		Cells:  cells,
+	}
+}
+
+// GetCell returns the cell at the given coordinates
+func (m *HexMaze) GetCell(x, y int) *HexCell {
+	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
+		return nil
+	}
+	return m.Cells[y][x]
+}
+
+// GetNeighbor returns the neighboring cell in the given direction
+func (m *HexMaze) GetNeighbor(cell *HexCell, dir HexDirection) *HexCell {
+	// Diagonal neighbors depend on whether the row is shifted
+	shift := cell.Y % 2
+
+	switch dir {
+	case HexNorthEast:
+		return m.GetCell(cell.X+shift, cell.Y-1)
+	case HexEast:
+		return m.GetCell(cell.X+1, cell.Y)
+	case HexSouthEast:
+		return m.GetCell(cell.X+shift, cell.Y+1)
+	case HexSouthWest:
+		return m.GetCell(cell.X+shift-1, cell.Y+1)
+	case HexWest:
+		return m.GetCell(cell.X-1, cell.Y)
+	case HexNorthWest:
+		return m.GetCell(cell.X+shift-1, cell.Y-1)
+	}
+	return nil
+}
+
+// directionTo returns the direction from one cell to an adjacent cell
+func (m *HexMaze) directionTo(from, to *HexCell) (HexDirection, bool) {
+	for _, dir := range hexDirections {
+		if m.GetNeighbor(from, dir) == to {
+			return dir, true
+		}
+	}
+	return 0, false
+}
+
+// RemoveWall removes the wall between two adjacent cells
+func (m *HexMaze) RemoveWall(cell1, cell2 *HexCell) {
+	if dir, ok := m.directionTo(cell1, cell2); ok {
+		cell1.Walls[dir] = false
+		cell2.Walls[dir.Opposite()] = false
+	}
+}
+
+// CanMove checks if movement is possible from one cell to another
+func (m *HexMaze) CanMove(from, to *HexCell) bool {
+	if from == nil || to == nil {
+		return false
+	}
+	dir, ok := m.directionTo(from, to)
+	return ok && !from.Walls[dir]
+}
+
+// GenerateHex creates a new hexagonal maze using recursive backtracking.
+// Start and finish are placed in opposite corners.
+func (g *Generator) GenerateHex(width, height int) *HexMaze {
+	maze := NewHexMaze(width, height)
+
+	// Start from a random cell and backtrack with an explicit stack
+	start := maze.GetCell(g.rng.Intn(width), g.rng.Intn(height))
+	start.Visited = true
+	stack := []*HexCell{start}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+
+		// Collect unvisited neighbors
+		var unvisited []*HexCell
+		for _, dir := range hexDirections {
+			neighbor := maze.GetNeighbor(current, dir)
+			if neighbor != nil && !neighbor.Visited {
+				unvisited = append(unvisited, neighbor)
+			}
+		}
+
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := unvisited[g.rng.Intn(len(unvisited))]
+		maze.RemoveWall(current, next)
+		next.Visited = true
+		stack = append(stack, next)
+	}
+
+	maze.Start = Point{0, 0}
+	maze.Finish = Point{width - 1, height - 1}
+
+	return maze
+}
+
+// HasHexPath checks if there's a valid path from start to finish in a hex maze using BFS
+func (v *Validator) HasHexPath(maze *HexMaze) bool {
+	if maze == nil {
+		return false
+	}
+
+	start := maze.GetCell(maze.Start.X, maze.Start.Y)
+	finish := maze.GetCell(maze.Finish.X, maze.Finish.Y)
+	if start == nil || finish == nil {
+		return false
+	}
+
+	// Queue for BFS
+	visited := map[*HexCell]bool{start: true}
+	queue := []*HexCell{start}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == finish {
+			return true
+		}
+
+		for _, dir := range hexDirections {
+			neighbor := maze.GetNeighbor(current, dir)
+			if neighbor != nil && !visited[neighbor] && maze.CanMove(current, neighbor) {
+				visited[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	// No path found
+	return false
+}
+
+// RenderHexToPNG renders a hexagonal maze to a PNG file.
+// CellSize is the distance between opposite flat sides of each hexagon.
+// ImageWidth and ImageHeight bound the image as they do for rectangular mazes.
+func (r *Renderer) RenderHexToPNG(maze *HexMaze, filename string) error {
+	return r.writePNG(r.createHexImage(maze), filename)
+}
+
+// createHexImage creates an image representation of a hexagonal maze
+func (r *Renderer) createHexImage(maze *HexMaze) *image.RGBA {
+	// Rows overlap by a quarter hexagon, whose radius is CellSize/√3
+	r = r.fitSquareCells(float64(maze.Width)+0.5, (1.5*float64(maze.Height-1)+2)/math.Sqrt(3))
+	cellWidth := float64(r.config.CellSize)
+	radius := cellWidth / math.Sqrt(3)
+
+	imgWidth := 2*r.config.Padding + int(math.Ceil(cellWidth*(float64(maze.Width)+0.5))) + r.config.WallThickness
+	imgHeight := r.headerHeight() + 2*r.config.Padding +
+		int(math.Ceil(radius*(1.5*float64(maze.Height-1)+2))) + r.config.WallThickness
+
+	// Create image with the configured background, border, and header
+	img := image.NewRGBA(image.Rect(0, 0, imgWidth, imgHeight))
+	r.fillBackground(img, image.Rect(r.config.Padding, r.headerHeight()+r.config.Padding,
+		imgWidth-r.config.Padding, imgHeight-r.config.Padding))
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+			centerX, centerY := r.hexCellCenter(x, y)
+			isEndpoint := (x == maze.Start.X && y == maze.Start.Y) || (x == maze.Finish.X && y == maze.Finish.Y)
+
+			for i, dir := range hexDirections {
+				if !cell.Walls[dir] {
+					continue
+				}
+
+				// Leave outer walls open at start and finish
+				if isEndpoint && maze.GetNeighbor(cell, dir) == nil {
+					continue
+				}
+
+				// Each edge runs between two corners, starting at the top corner for north-east
+				startAngle := math.Pi * (-90 + 60*float64(i)) / 180
+				endAngle := startAngle + math.Pi/3
+				r.drawLine(img,
+					centerX+radius*math.Cos(startAngle), centerY+radius*math.Sin(startAngle),
+					centerX+radius*math.Cos(endAngle), centerY+radius*math.Sin(endAngle))
+			}
+		}
+	}
+
+	// Draw start and finish markers
+	startX, startY := r.hexCellCenter(maze.Start.X, maze.Start.Y)
+	r.drawMarkerAt(img, r.config.StartMarker, int(math.Round(startX)), int(math.Round(startY)))
+	finishX, finishY := r.hexCellCenter(maze.Finish.X, maze.Finish.Y)
+	r.drawMarkerAt(img, r.config.FinishMarker, int(math.Round(finishX)), int(math.Round(finishY)))
+
+	return img
+}
+
+// hexCellCenter returns the pixel position of the center of a hex cell
+func (r *Renderer) hexCellCenter(x, y int) (float64, float64) {
+	cellWidth := float64(r.config.CellSize)
+	radius := cellWidth / math.Sqrt(3)
+	offset := float64(r.config.WallThickness) / 2
+
+	centerX := float64(r.config.Padding) + offset + cellWidth/2 + float64(x)*cellWidth
+	if y%2 == 1 {
+		centerX += cellWidth / 2
+	}
+	centerY := float64(r.config.Padding+r.headerHeight()) + offset + radius + float64(y)*1.5*radius
+
+	return centerX, centerY
+}
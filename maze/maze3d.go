@@ -0,0 +1,230 @@
+package maze
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Point3D represents a coordinate in a multi-level maze
+type Point3D struct {
+	X, Y, Z int
+}
+
+// Maze3D represents a stack of maze levels connected by stairs.
+// Each level is an ordinary Maze; Up and Down record whether the ceiling or
+// floor of each cell is closed, with true meaning a wall like Cell.HasWall.
+type Maze3D struct {
+	Width, Height, Depth int
+	Levels               []*Maze
+	Up, Down             map[Point3D]bool
+	Start, Finish        Point3D
+}
+
+// NewMaze3D creates a new multi-level maze with every wall, floor, and ceiling intact
+func NewMaze3D(width, height, depth int) *Maze3D {
+	m := &Maze3D{
+		Width:  width,
+		Height: height,
+		Depth:  depth,
+		Levels: make([]*Maze, depth),
+		Up:     make(map[Point3D]bool),
+		Down:   make(map[Point3D]bool),
+	}
+
+	for z := 0; z < depth; z++ {
+		m.Levels[z] = NewMaze(width, height)
+
+		// Levels only show a start or finish if it sits on them
+		m.Levels[z].Start = Point{-1, -1}
+		m.Levels[z].Finish = Point{-1, -1}
+
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				m.Up[Point3D{x, y, z}] = true
+				m.Down[Point3D{x, y, z}] = true
+			}
+		}
+	}
+
+	return m
+}
+
+// InBounds reports whether the point lies inside the maze
+func (m *Maze3D) InBounds(p Point3D) bool {
+	return p.X >= 0 && p.X < m.Width && p.Y >= 0 && p.Y < m.Height && p.Z >= 0 && p.Z < m.Depth
+}
+
+// Neighbors returns all in-bounds points adjacent to p, including the levels above and below
+func (m *Maze3D) Neighbors(p Point3D) []Point3D {
+	candidates := []Point3D{
+		{p.X, p.Y - 1, p.Z},
+		{p.X + 1, p.Y, p.Z},
+		{p.X, p.Y + 1, p.Z},
+		{p.X - 1, p.Y, p.Z},
+		{p.X, p.Y, p.Z + 1},
+		{p.X, p.Y, p.Z - 1},
+	}
+
+	var neighbors []Point3D
+	for _, c := range candidates {
+		if m.InBounds(c) {
+			neighbors = append(neighbors, c)
+		}
+	}
+	return neighbors
+}
+
+// RemoveWall opens the passage between two adjacent points, adding stairs if they are on different levels
+func (m *Maze3D) RemoveWall(a, b Point3D) {
+	switch {
+	case a.Z == b.Z:
+		level := m.Levels[a.Z]
+		level.RemoveWall(level.GetCell(a.X, a.Y), level.GetCell(b.X, b.Y))
+	case b.Z == a.Z+1:
+		m.Up[a] = false
+		m.Down[b] = false
+	case b.Z == a.Z-1:
+		m.Down[a] = false
+		m.Up[b] = false
+	}
+}
+
+// CanMove checks if movement is possible between two adjacent points
+func (m *Maze3D) CanMove(from, to Point3D) bool {
+	if !m.InBounds(from) || !m.InBounds(to) {
+		return false
+	}
+
+	switch {
+	case from.Z == to.Z:
+		level := m.Levels[from.Z]
+		return level.CanMove(level.GetCell(from.X, from.Y), level.GetCell(to.X, to.Y))
+	case from.X != to.X || from.Y != to.Y:
+		return false
+	case to.Z == from.Z+1:
+		return !m.Up[from]
+	case to.Z == from.Z-1:
+		return !m.Down[from]
+	}
+	return false
+}
+
+// setStartFinish sets the start and finish and mirrors them onto their levels
+func (m *Maze3D) setStartFinish(start, finish Point3D) {
+	m.Start = start
+	m.Finish = finish
+	m.Levels[start.Z].Start = Point{start.X, start.Y}
+	m.Levels[finish.Z].Finish = Point{finish.X, finish.Y}
+}
+
+// Generate3D creates a new multi-level maze using recursive backtracking across all levels.
+// The start is placed on level 0 and the finish on the top level; since the
+// result is a perfect maze they are always connected.
+func (g *Generator) Generate3D(width, height, depth int) *Maze3D {
+	maze := NewMaze3D(width, height, depth)
+
+	// Start from a random point and backtrack with an explicit stack
+	start := Point3D{g.rng.Intn(width), g.rng.Intn(height), g.rng.Intn(depth)}
+	visited := map[Point3D]bool{start: true}
+	stack := []Point3D{start}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+
+		// Collect unvisited neighbors on this level and the ones above and below
+		var unvisited []Point3D
+		for _, neighbor := range maze.Neighbors(current) {
+			if !visited[neighbor] {
+				unvisited = append(unvisited, neighbor)
+			}
+		}
+
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := unvisited[g.rng.Intn(len(unvisited))]
+		maze.RemoveWall(current, next)
+		visited[next] = true
+		stack = append(stack, next)
+	}
+
+	// Mark all cells as visited to match the state left by Generate
+	for _, level := range maze.Levels {
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				level.Cells[y][x].Visited = true
+			}
+		}
+	}
+
+	maze.setStartFinish(Point3D{0, 0, 0}, Point3D{width - 1, height - 1, depth - 1})
+
+	return maze
+}
+
+// HasPath3D checks if there's a valid path from start to finish, using stairs as moves
+func (v *Validator) HasPath3D(maze *Maze3D) bool {
+	if maze == nil || !maze.InBounds(maze.Start) || !maze.InBounds(maze.Finish) {
+		return false
+	}
+
+	// Queue for BFS
+	visited := map[Point3D]bool{maze.Start: true}
+	queue := []Point3D{maze.Start}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == maze.Finish {
+			return true
+		}
+
+		for _, neighbor := range maze.Neighbors(current) {
+			if !visited[neighbor] && maze.CanMove(current, neighbor) {
+				visited[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	// No path found
+	return false
+}
+
+// RenderToASCII3D renders each level of a multi-level maze as plain text.
+// Cells are marked 'S' and 'F' for start and finish, 'U' for stairs up,
+// 'D' for stairs down, and 'X' for stairs in both directions.
+func (r *Renderer) RenderToASCII3D(maze *Maze3D) string {
+	var sb strings.Builder
+
+	for z, level := range maze.Levels {
+		fmt.Fprintf(&sb, "Level %d\n", z)
+		sb.WriteString(renderASCII(level, func(x, y int) byte {
+			p := Point3D{x, y, z}
+			up, down := !maze.Up[p], !maze.Down[p]
+
+			switch {
+			case p == maze.Start:
+				return 'S'
+			case p == maze.Finish:
+				return 'F'
+			case up && down:
+				return 'X'
+			case up:
+				return 'U'
+			case down:
+				return 'D'
+			default:
+				return ' '
+			}
+		}))
+		if z < len(maze.Levels)-1 {
+			sb.WriteByte('\n')
+		}
+	}
+
+	return sb.String()
+}
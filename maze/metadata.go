@@ -0,0 +1,57 @@
+package maze
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Metadata describes a generated maze for indexing alongside its image
+type Metadata struct {
+	Seed           int64               `json:"seed"`
+	Algorithm      GenerationAlgorithm `json:"algorithm"`
+	Width          int                 `json:"width"`
+	Height         int                 `json:"height"`
+	Start          Point               `json:"start"`
+	Finish         Point               `json:"finish"`
+	Finishes       []Point             `json:"finishes,omitempty"`
+	SolutionLength int                 `json:"solutionLength"` // Steps from start to finish, or -1 if unsolvable
+}
+
+// NewMetadata collects the metadata of a maze generated with the given seed and algorithm
+func NewMetadata(maze *Maze, seed int64, algo GenerationAlgorithm) Metadata {
+	return Metadata{
+		Seed:           seed,
+		Algorithm:      algo,
+		Width:          maze.Width,
+		Height:         maze.Height,
+		Start:          maze.Start,
+		Finish:         maze.Finish,
+		Finishes:       maze.Finishes,
+		SolutionLength: NewAnalyzer().PathLength(maze),
+	}
+}
+
+// MetadataFilename returns the path of the JSON sidecar for an image,
+// replacing its extension with .json
+func MetadataFilename(imageFilename string) string {
+	return strings.TrimSuffix(imageFilename, filepath.Ext(imageFilename)) + ".json"
+}
+
+// RenderToPNGWithMetadata renders the maze to a PNG file and writes its
+// metadata to a companion JSON file named by MetadataFilename. Pass the seed
+// from Generator.Seed and the algorithm the maze was generated with; the seed
+// only reproduces the maze if it was the generator's first, so use a fresh
+// generator per maze when the metadata must be reproducible.
+func (r *Renderer) RenderToPNGWithMetadata(maze *Maze, filename string, seed int64, algo GenerationAlgorithm) error {
+	if err := r.RenderToPNG(maze, filename); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(NewMetadata(maze, seed, algo), "", "  ")
+	if err != nil {
+		return renderError(MetadataFilename(filename), err)
+	}
+	return renderError(MetadataFilename(filename), os.WriteFile(MetadataFilename(filename), data, 0o644))
+}
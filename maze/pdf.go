@@ -0,0 +1,233 @@
+package maze
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"strings"
+)
+
+// PageSize selects the paper format for PDF output
+type PageSize int
+
+const (
+	Letter PageSize = iota
+	A4
+	A3
+)
+
+// pdfMargin is the page margin in points (half an inch)
+const pdfMargin = 36
+
+// pdfCourierAdvance is the advance width of every Courier glyph as a fraction of the font size
+const pdfCourierAdvance = 0.6
+
+// pdfCourierCapHeight is the cap height of Courier as a fraction of the font size
+const pdfCourierCapHeight = 0.57
+
+// pdfCircleKappa places Bezier control points so four curves approximate a circle or ellipse
+const pdfCircleKappa = 0.5523
+
+// Dimensions returns the page width and height in points
+func (p PageSize) Dimensions() (float64, float64) {
+	switch p {
+	case A4:
+		return 595.28, 841.89
+	case A3:
+		return 841.89, 1190.55
+	default:
+		return 612, 792
+	}
+}
+
+// RenderToPDF renders the maze as vector graphics on a single PDF page.
+// The layout matches the PNG output and is scaled uniformly to fit the page
+// inside a half-inch margin, so it prints sharply at any size.
+func (r *Renderer) RenderToPDF(maze *Maze, filename string, page PageSize) error {
+	r = r.fitToSize(maze)
+	var content bytes.Buffer
+	r.writePDFContent(&content, maze, page)
+
+	pageWidth, pageHeight := page.Dimensions()
+
+	// Assemble the document objects; the content stream is object 4
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R >>",
+			pageWidth, pageHeight),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>",
+	}
+
+	var doc bytes.Buffer
+	doc.WriteString("%PDF-1.4\n")
+
+	// Record each object's byte offset for the cross-reference table
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = doc.Len()
+		fmt.Fprintf(&doc, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := doc.Len()
+	fmt.Fprintf(&doc, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&doc, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&doc, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return renderError(filename, os.WriteFile(filename, doc.Bytes(), 0644))
+}
+
+// writePDFContent writes the page content stream for the maze
+func (r *Renderer) writePDFContent(w *bytes.Buffer, maze *Maze, page PageSize) {
+	width, height := r.GetImageDimensions(maze)
+	pageWidth, pageHeight := page.Dimensions()
+
+	// Fit the pixel layout into the printable area, centered on the page
+	printWidth := pageWidth - 2*pdfMargin
+	printHeight := pageHeight - 2*pdfMargin
+	scale := min(printWidth/float64(width), printHeight/float64(height))
+	offsetX := (pageWidth - float64(width)*scale) / 2
+	offsetY := (pageHeight + float64(height)*scale) / 2
+
+	// Flip the y axis so everything below can use the same top-down pixel coordinates
+	fmt.Fprintf(w, "q\n%g 0 0 %g %g %g cm\n", scale, -scale, offsetX, offsetY)
+
+	// Background, border, and header; the background gradient is not supported here
+	background := image.Rect(0, 0, width, height)
+	if r.config.BorderColor != nil {
+		fmt.Fprintf(w, "%s rg\n", pdfColor(r.config.BorderColor))
+		writePDFRect(w, background)
+		background = r.mazeBounds(maze)
+	}
+	fmt.Fprintf(w, "%s rg\n", pdfColor(r.config.PathColor))
+	writePDFRect(w, background)
+	headerColor := r.headerColor()
+	if headerColor == nil {
+		headerColor = r.config.PathColor
+	}
+	fmt.Fprintf(w, "%s rg\n", pdfColor(headerColor))
+	writePDFRect(w, image.Rect(0, 0, width, r.headerHeight()))
+
+	// Legend text, using the ASCII symbols since the standard fonts lack the Unicode ones
+	fontSize := basicFontHeight * r.config.LegendFontSize
+	if r.config.ShowLegend {
+		r.writePDFText(w, r.legendText(false), fontSize, image.Rect(0, 0, width, r.headerHeight()))
+	}
+
+	// Guide grid beneath the walls
+	if r.config.ShowGrid {
+		fmt.Fprintf(w, "%s rg\n", pdfColor(r.gridColor()))
+		for _, line := range r.gridLines(maze) {
+			writePDFRect(w, line)
+		}
+	}
+
+	// Walls
+	fmt.Fprintf(w, "%s rg\n", pdfColor(r.config.WallColor))
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+			for _, dir := range AllDirections() {
+				if r.wallVisible(maze, cell, dir) {
+					writePDFRect(w, r.wallRect(x, y, dir))
+				}
+			}
+		}
+	}
+
+	// Start and finish markers, drawn as outlines
+	fmt.Fprintf(w, "%s RG\n%d w\n", pdfColor(r.config.WallColor), markerThickness)
+	startX, startY := r.cellCenter(maze.Start)
+	r.writePDFMarker(w, r.config.StartMarker, startX, startY)
+	for _, finish := range maze.AllFinishes() {
+		finishX, finishY := r.cellCenter(finish)
+		r.writePDFMarker(w, r.config.FinishMarker, finishX, finishY)
+	}
+
+	// Scale bar footer
+	if r.config.ScaleBar {
+		bars, label, labelArea := r.scaleBarLayout(maze, width, height)
+		fmt.Fprintf(w, "%s rg\n", pdfColor(r.config.WallColor))
+		for _, bar := range bars {
+			writePDFRect(w, bar)
+		}
+		r.writePDFText(w, label, fontSize, labelArea)
+	}
+
+	w.WriteString("Q\n")
+}
+
+// writePDFMarker writes a stroked marker outline centered at the given position
+func (r *Renderer) writePDFMarker(w *bytes.Buffer, shape MarkerShape, centerX, centerY int) {
+	half := float64(markerThickness) / 2
+	radiusX, radiusY := r.markerRadii()
+	rx, ry := float64(radiusX), float64(radiusY)
+	x, y := float64(centerX), float64(centerY)
+
+	switch shape {
+	case Square:
+		halfWidth, halfHeight := r.squareMarkerHalfSize()
+		fmt.Fprintf(w, "%g %g %g %g re S\n",
+			float64(centerX-halfWidth)+half, float64(centerY-halfHeight)+half,
+			float64(2*halfWidth)-2*half, float64(2*halfHeight)-2*half)
+	case Triangle, Diamond:
+		for i, v := range markerPolygon(shape, x, y, rx-half, ry-half) {
+			op := "l"
+			if i == 0 {
+				op = "m"
+			}
+			fmt.Fprintf(w, "%g %g %s\n", v[0], v[1], op)
+		}
+		w.WriteString("s\n")
+	case Cross:
+		armX, armY := markerCrossArm(rx), markerCrossArm(ry)
+		fmt.Fprintf(w, "%g %g m\n%g %g l\n%g %g m\n%g %g l\nS\n",
+			x-armX, y-armY, x+armX, y+armY, x-armX, y+armY, x+armX, y-armY)
+	default:
+		writePDFEllipse(w, x, y, rx-half, ry-half)
+	}
+}
+
+// writePDFText writes text in Courier centered in the given area.
+// The text matrix flips the y axis back so glyphs are upright under the page transform.
+func (r *Renderer) writePDFText(w *bytes.Buffer, text string, fontSize int, area image.Rectangle) {
+	size := float64(fontSize)
+	textWidth := float64(len(text)) * pdfCourierAdvance * size
+	x := float64(area.Min.X+area.Dx()/2) - textWidth/2
+	baseline := float64(area.Min.Y+area.Dy()/2) + pdfCourierCapHeight*size/2
+
+	fmt.Fprintf(w, "%s rg\nBT\n/F1 %d Tf\n1 0 0 -1 %g %g Tm\n(%s) Tj\nET\n",
+		pdfColor(r.config.TextColor), fontSize, x, baseline, pdfEscape(text))
+}
+
+// writePDFRect writes a filled rectangle covering the given rectangle
+func writePDFRect(w *bytes.Buffer, rect image.Rectangle) {
+	fmt.Fprintf(w, "%d %d %d %d re f\n", rect.Min.X, rect.Min.Y, rect.Dx(), rect.Dy())
+}
+
+// writePDFEllipse writes a stroked ellipse built from four Bezier curves
+func writePDFEllipse(w *bytes.Buffer, cx, cy, rx, ry float64) {
+	kx, ky := pdfCircleKappa*rx, pdfCircleKappa*ry
+	fmt.Fprintf(w, "%g %g m\n", cx+rx, cy)
+	fmt.Fprintf(w, "%g %g %g %g %g %g c\n", cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry)
+	fmt.Fprintf(w, "%g %g %g %g %g %g c\n", cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy)
+	fmt.Fprintf(w, "%g %g %g %g %g %g c\n", cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry)
+	fmt.Fprintf(w, "%g %g %g %g %g %g c\n", cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy)
+	w.WriteString("S\n")
+}
+
+// pdfColor converts a color to PDF RGB operands in the 0-1 range
+func pdfColor(c color.Color) string {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	return fmt.Sprintf("%.3f %.3f %.3f", float64(rgba.R)/255, float64(rgba.G)/255, float64(rgba.B)/255)
+}
+
+// pdfEscape escapes the characters that are special inside a PDF string literal
+func pdfEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(text)
+}
@@ -0,0 +1,305 @@
+package maze
+
+import (
+	"image"
+	"math"
+)
+
+// PolarPoint represents a cell position in a circular maze
+type PolarPoint struct {
+	Ring, Index int
+}
+
+// PolarCell represents a single cell in a circular maze
+type PolarCell struct {
+	Ring, Index   int
+	Visited       bool
+	InwardWall    bool // Wall between this cell and the ring inside it
+	ClockwiseWall bool // Wall between this cell and its clockwise neighbor
+}
+
+// PolarMaze represents a circular maze made of concentric rings.
+// Ring 0 is a single center cell. Each outer ring is subdivided so its cells
+// stay roughly square, and every cell in a ring maps to one parent cell in the
+// ring inside it.
+type PolarMaze struct {
+	Rings         int
+	Cells         [][]*PolarCell // Cells[ring][index]
+	Start, Finish PolarPoint
+}
+
+// NewPolarMaze creates a new circular maze with the given number of rings and all walls intact
+func NewPolarMaze(rings int) *PolarMaze {
+	cells := make([][]*PolarCell, rings)
+	ringHeight := 1.0 / float64(rings)
+
+	for ring := 0; ring < rings; ring++ {
+		count := 1
+		if ring > 0 {
+			// Split cells whenever they would become much wider than they are tall
+			previous := len(cells[ring-1])
+			circumference := 2 * math.Pi * float64(ring) / float64(rings)
+			estimatedWidth := circumference / float64(previous)
+			ratio := max(int(math.Round(estimatedWidth/ringHeight)), 1)
+			count = previous * ratio
+		}
+
+		cells[ring] = make([]*PolarCell, count)
+		for index := 0; index < count; index++ {
+			cells[ring][index] = &PolarCell{
+				Ring:          ring,
+				Index:         index,
+				InwardWall:    ring > 0,
+				ClockwiseWall: count > 1,
+			}
+		}
+	}
+
+	return &PolarMaze{
+		Rings: rings,
+		Cells: cells,
+	}
+}
+
+// GetCell returns the cell at the given ring and index, wrapping the index around the ring
+func (m *PolarMaze) GetCell(ring, index int) *PolarCell {
+	if ring < 0 || ring >= m.Rings {
+		return nil
+	}
+	count := len(m.Cells[ring])
+	return m.Cells[ring][((index%count)+count)%count]
+}
+
+// Neighbors returns all cells adjacent to the given cell
+func (m *PolarMaze) Neighbors(cell *PolarCell) []*PolarCell {
+	var neighbors []*PolarCell
+
+	// Clockwise and counter-clockwise neighbors in the same ring
+	if len(m.Cells[cell.Ring]) > 1 {
+		neighbors = append(neighbors,
+			m.GetCell(cell.Ring, cell.Index+1),
+			m.GetCell(cell.Ring, cell.Index-1),
+		)
+	}
+
+	// Parent cell in the ring inside
+	if parent := m.parent(cell); parent != nil {
+		neighbors = append(neighbors, parent)
+	}
+
+	// Child cells in the ring outside
+	neighbors = append(neighbors, m.children(cell)...)
+
+	return neighbors
+}
+
+// parent returns the cell in the next ring inward that this cell borders
+func (m *PolarMaze) parent(cell *PolarCell) *PolarCell {
+	if cell.Ring == 0 {
+		return nil
+	}
+	ratio := len(m.Cells[cell.Ring]) / len(m.Cells[cell.Ring-1])
+	return m.Cells[cell.Ring-1][cell.Index/ratio]
+}
+
+// children returns the cells in the next ring outward that border this cell
+func (m *PolarMaze) children(cell *PolarCell) []*PolarCell {
+	if cell.Ring+1 >= m.Rings {
+		return nil
+	}
+	ratio := len(m.Cells[cell.Ring+1]) / len(m.Cells[cell.Ring])
+	return m.Cells[cell.Ring+1][cell.Index*ratio : (cell.Index+1)*ratio]
+}
+
+// RemoveWall removes the wall between two adjacent cells
+func (m *PolarMaze) RemoveWall(cell1, cell2 *PolarCell) {
+	if cell1.Ring == cell2.Ring {
+		// The clockwise wall belongs to the counter-clockwise cell of the pair
+		if m.GetCell(cell1.Ring, cell1.Index+1) == cell2 {
+			cell1.ClockwiseWall = false
+		} else {
+			cell2.ClockwiseWall = false
+		}
+		return
+	}
+
+	// The inward wall belongs to the outer cell of the pair
+	if cell1.Ring > cell2.Ring {
+		cell1.InwardWall = false
+	} else {
+		cell2.InwardWall = false
+	}
+}
+
+// CanMove checks if movement is possible from one cell to another
+func (m *PolarMaze) CanMove(from, to *PolarCell) bool {
+	if from == nil || to == nil {
+		return false
+	}
+
+	if from.Ring == to.Ring && len(m.Cells[from.Ring]) > 1 {
+		if m.GetCell(from.Ring, from.Index+1) == to {
+			return !from.ClockwiseWall
+		}
+		if m.GetCell(from.Ring, from.Index-1) == to {
+			return !to.ClockwiseWall
+		}
+	}
+	if m.parent(from) == to {
+		return !from.InwardWall
+	}
+	if m.parent(to) == from {
+		return !to.InwardWall
+	}
+	return false
+}
+
+// GeneratePolar creates a new circular maze using recursive backtracking.
+// The start is placed in the center and the finish in a random outer-ring cell.
+func (g *Generator) GeneratePolar(rings int) *PolarMaze {
+	maze := NewPolarMaze(rings)
+
+	// Start from a random cell and backtrack with an explicit stack
+	startRing := g.rng.Intn(rings)
+	start := maze.GetCell(startRing, g.rng.Intn(len(maze.Cells[startRing])))
+	start.Visited = true
+	stack := []*PolarCell{start}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+
+		// Collect unvisited neighbors
+		var unvisited []*PolarCell
+		for _, neighbor := range maze.Neighbors(current) {
+			if !neighbor.Visited {
+				unvisited = append(unvisited, neighbor)
+			}
+		}
+
+		if len(unvisited) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := unvisited[g.rng.Intn(len(unvisited))]
+		maze.RemoveWall(current, next)
+		next.Visited = true
+		stack = append(stack, next)
+	}
+
+	outer := rings - 1
+	maze.Start = PolarPoint{0, 0}
+	maze.Finish = PolarPoint{outer, g.rng.Intn(len(maze.Cells[outer]))}
+
+	return maze
+}
+
+// RenderPolarToPNG renders a circular maze to a PNG file.
+// The outer wall is left open at the finish cell. ImageWidth and ImageHeight
+// bound the image as they do for rectangular mazes.
+func (r *Renderer) RenderPolarToPNG(maze *PolarMaze, filename string) error {
+	return r.writePNG(r.createPolarImage(maze), filename)
+}
+
+// createPolarImage creates an image representation of a circular maze
+func (r *Renderer) createPolarImage(maze *PolarMaze) *image.RGBA {
+	r = r.fitSquareCells(float64(2*maze.Rings), float64(2*maze.Rings))
+	ringSize := r.config.CellSize
+	diameter := 2*maze.Rings*ringSize + r.config.WallThickness + 2*r.config.Padding
+	imgWidth := diameter
+	imgHeight := diameter + r.headerHeight()
+
+	// Create image with the configured background, border, and header
+	img := image.NewRGBA(image.Rect(0, 0, imgWidth, imgHeight))
+	r.fillBackground(img, image.Rect(r.config.Padding, r.headerHeight()+r.config.Padding,
+		imgWidth-r.config.Padding, imgHeight-r.config.Padding))
+
+	centerX := float64(imgWidth) / 2
+	centerY := float64(r.headerHeight()) + float64(diameter)/2
+
+	for ring := 1; ring < maze.Rings; ring++ {
+		count := len(maze.Cells[ring])
+		innerRadius := float64(ring * ringSize)
+		outerRadius := float64((ring + 1) * ringSize)
+
+		for _, cell := range maze.Cells[ring] {
+			startAngle := 2 * math.Pi * float64(cell.Index) / float64(count)
+			endAngle := 2 * math.Pi * float64(cell.Index+1) / float64(count)
+
+			if cell.InwardWall {
+				r.drawArc(img, centerX, centerY, innerRadius, startAngle, endAngle)
+			}
+			if cell.ClockwiseWall {
+				x1, y1 := polarToPixel(centerX, centerY, innerRadius, endAngle)
+				x2, y2 := polarToPixel(centerX, centerY, outerRadius, endAngle)
+				r.drawLine(img, x1, y1, x2, y2)
+			}
+		}
+	}
+
+	// Draw the outer boundary, leaving an opening at the finish
+	outer := maze.Rings - 1
+	outerCount := len(maze.Cells[outer])
+	outerRadius := float64(maze.Rings * ringSize)
+	for index := 0; index < outerCount; index++ {
+		if outer == maze.Finish.Ring && index == maze.Finish.Index {
+			continue
+		}
+		startAngle := 2 * math.Pi * float64(index) / float64(outerCount)
+		endAngle := 2 * math.Pi * float64(index+1) / float64(outerCount)
+		r.drawArc(img, centerX, centerY, outerRadius, startAngle, endAngle)
+	}
+
+	// Draw start and finish markers at their cell centers
+	startX, startY := r.polarCellCenter(maze, maze.Start, centerX, centerY)
+	r.drawMarkerAt(img, r.config.StartMarker, startX, startY)
+	finishX, finishY := r.polarCellCenter(maze, maze.Finish, centerX, centerY)
+	r.drawMarkerAt(img, r.config.FinishMarker, finishX, finishY)
+
+	return img
+}
+
+// polarCellCenter returns the pixel position of the center of a polar cell
+func (r *Renderer) polarCellCenter(maze *PolarMaze, pos PolarPoint, centerX, centerY float64) (int, int) {
+	if pos.Ring == 0 {
+		return int(math.Round(centerX)), int(math.Round(centerY))
+	}
+
+	count := len(maze.Cells[pos.Ring])
+	radius := (float64(pos.Ring) + 0.5) * float64(r.config.CellSize)
+	angle := 2 * math.Pi * (float64(pos.Index) + 0.5) / float64(count)
+	x, y := polarToPixel(centerX, centerY, radius, angle)
+	return int(math.Round(x)), int(math.Round(y))
+}
+
+// polarToPixel converts a radius and clockwise angle from the top into pixel coordinates
+func polarToPixel(centerX, centerY, radius, angle float64) (float64, float64) {
+	return centerX + radius*math.Sin(angle), centerY - radius*math.Cos(angle)
+}
+
+// drawArc draws a wall along a circle between two clockwise angles measured from the top
+func (r *Renderer) drawArc(img *image.RGBA, centerX, centerY, radius, startAngle, endAngle float64) {
+	// Step roughly one pixel along the arc
+	steps := max(int(radius*(endAngle-startAngle)), 1)
+	for i := 0; i <= steps; i++ {
+		angle := startAngle + (endAngle-startAngle)*float64(i)/float64(steps)
+		x, y := polarToPixel(centerX, centerY, radius, angle)
+		r.stampWall(img, x, y)
+	}
+}
+
+// drawLine draws a wall along a straight line between two pixel positions
+func (r *Renderer) drawLine(img *image.RGBA, x1, y1, x2, y2 float64) {
+	// Step roughly one pixel along the line
+	steps := max(int(math.Hypot(x2-x1, y2-y1)), 1)
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		r.stampWall(img, x1+(x2-x1)*t, y1+(y2-y1)*t)
+	}
+}
+
+// stampWall draws a round dot of wall thickness at the given pixel position
+func (r *Renderer) stampWall(img *image.RGBA, x, y float64) {
+	radius := max(r.config.WallThickness/2, 1)
+	r.drawFilledCircle(img, int(math.Round(x)), int(math.Round(y)), radius, r.config.WallColor)
+}
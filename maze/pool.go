@@ -0,0 +1,33 @@
+package maze
+
+// GeneratorPool hands out generators to concurrent callers.
+// A Generator is not safe for concurrent use, so the pool owns a fixed set of
+// them, each with its own rng, and lends one to each call for its duration.
+type GeneratorPool struct {
+	generators chan *Generator
+}
+
+// NewGeneratorPool creates a pool of size independently seeded generators.
+// At most size mazes are generated at once; extra callers wait for a free generator.
+func NewGeneratorPool(size int) *GeneratorPool {
+	if size < 1 {
+		size = 1
+	}
+
+	pool := &GeneratorPool{
+		generators: make(chan *Generator, size),
+	}
+	for i := 0; i < size; i++ {
+		pool.generators <- NewGenerator()
+	}
+	return pool
+}
+
+// Generate creates a validated maze using a generator borrowed from the pool,
+// failing like GenerateWithValidation. It is safe to call from many goroutines.
+func (p *GeneratorPool) Generate(width, height int) (*Maze, error) {
+	g := <-p.generators
+	defer func() { p.generators <- g }()
+
+	return g.GenerateWithValidation(width, height, defaultRetries)
+}
@@ -0,0 +1,38 @@
+package maze
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestGeneratorPoolConcurrent is meant to be run with go test -race
+func TestGeneratorPoolConcurrent(t *testing.T) {
+	pool := NewGeneratorPool(4)
+	v := NewValidator()
+
+	const workers, perWorker = 16, 8
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*perWorker)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perWorker {
+				m, err := pool.Generate(10, 10)
+				if err != nil {
+					errs <- err
+					continue
+				}
+				if !v.HasPath(m) {
+					t.Error("pooled maze has no path from start to finish")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -1,26 +1,46 @@
 package maze
 
 import (
+	"errors"
+	"fmt"
 	"image"
+	"image/color"
 	"image/draw"
 	"image/png"
+	"io"
+	"math"
 	"os"
 
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/opentype"
 	"golang.org/x/image/math/fixed"
 )
 
+// scaleBarCells is the number of cells spanned by the scale bar
+const scaleBarCells = 5
+
+// basicFontHeight is the pixel height of the fallback basic font before scaling
+const basicFontHeight = 13
+
+// markerThickness is the line thickness of the start and finish marker outlines
+const markerThickness = 3
+
+// markerSamples is the number of samples per pixel along each axis when anti-aliasing markers
+const markerSamples = 4
+
 // Renderer handles converting maze data to PNG images
 type Renderer struct {
 	config   RenderConfig
 	fontFace font.Face
 }
 
-// NewRenderer creates a new maze renderer with the given configuration
+// NewRenderer creates a new maze renderer with the given configuration.
+// Invalid fields (see RenderConfig.Validate) are replaced with their defaults
+// so the renderer never produces a degenerate image.
 func NewRenderer(config RenderConfig) *Renderer {
 	r := &Renderer{
-		config: config,
+		config: config.withDefaults(),
 	}
 
 	// Try to load a Unicode-capable font, fallback to basic font
@@ -34,59 +54,353 @@ func NewDefaultRenderer() *Renderer {
 	return NewRenderer(DefaultRenderConfig())
 }
 
-// RenderToPNG renders the maze to a PNG file
+// RenderToPNG renders the maze to a PNG file.
+// Failures are reported as a *RenderError.
 func (r *Renderer) RenderToPNG(maze *Maze, filename string) error {
+	r = r.fitToSize(maze)
+	return r.writePNG(r.createImage(maze), filename)
+}
+
+// RenderToWriter renders the maze as PNG data to any writer, such as an HTTP response.
+// Failures are reported as a *RenderError.
+func (r *Renderer) RenderToWriter(maze *Maze, w io.Writer) error {
+	r = r.fitToSize(maze)
+	return renderError("", r.encodePNG(w, r.createImage(maze)))
+}
+
+// RenderJunctionHintsToPNG renders the maze with a small dot on every junction cell.
+// This marks the decision points without revealing the solution.
+func (r *Renderer) RenderJunctionHintsToPNG(maze *Maze, filename string) error {
+	r = r.fitToSize(maze)
 	img := r.createImage(maze)
 
+	for _, pos := range NewAnalyzer().junctionCells(maze) {
+		centerX, centerY := r.cellCenter(pos)
+		r.drawFilledCircle(img, centerX, centerY, min(r.cellWidth(), r.cellHeight())/8, r.config.JunctionColor)
+	}
+
+	return r.writePNG(img, filename)
+}
+
+// RenderSolutionToPNG renders the maze with the solution path drawn from start to finish.
+// This is intended for producing answer keys alongside the blank puzzle.
+func (r *Renderer) RenderSolutionToPNG(maze *Maze, filename string) error {
+	path := NewValidator().FindPath(maze)
+	return r.RenderToPNGWithPaths(maze, [][]Point{path}, []color.Color{r.config.SolutionColor}, filename)
+}
+
+// RenderToPNGWithPaths renders the maze with each path overlaid in its own color.
+// Later paths are drawn on top of earlier ones, and colors are reused in order
+// if there are more paths than colors. Lines are SolutionThickness wide, with
+// arrowheads every SolutionArrowSpacing cells when that is set.
+func (r *Renderer) RenderToPNGWithPaths(maze *Maze, paths [][]Point, colors []color.Color, filename string) error {
+	r = r.fitToSize(maze)
+	if len(paths) > 0 && len(colors) == 0 {
+		return renderError(filename, errors.New("no colors provided for paths"))
+	}
+
+	img := r.createImage(maze)
+
+	for i, path := range paths {
+		c := colors[i%len(colors)]
+		r.drawPath(img, path, c, r.solutionThickness())
+		r.drawArrowheads(img, path, c)
+	}
+
+	// Redraw markers so they stay visible above the paths
+	r.drawMarkers(img, maze)
+
+	return r.writePNG(img, filename)
+}
+
+// drawPath draws a line of the given thickness connecting the centers of consecutive path cells
+func (r *Renderer) drawPath(img *image.RGBA, path []Point, c color.Color, thickness int) {
+	pathColor := &image.Uniform{c}
+	half := thickness / 2
+
+	for i := 1; i < len(path); i++ {
+		x1, y1 := r.cellCenter(path[i-1])
+		x2, y2 := r.cellCenter(path[i])
+
+		// Diagonal steps get a straight slanted line with rounded ends
+		if dir, ok := directionOf(path[i].X-path[i-1].X, path[i].Y-path[i-1].Y); ok && dir.IsDiagonal() {
+			steps := max(x2-x1, x1-x2, y2-y1, y1-y2)
+			for s := 0; s <= steps; s++ {
+				x := x1 + (x2-x1)*s/steps
+				y := y1 + (y2-y1)*s/steps
+				r.drawFilledCircle(img, x, y, max(half, 1), c)
+			}
+			continue
+		}
+
+		// Draw horizontal then vertical segment; adjacent cells only need one of them
+		horizontal := image.Rect(min(x1, x2)-half, y1-half, max(x1, x2)+thickness-half, y1+thickness-half)
+		draw.Draw(img, horizontal, pathColor, image.Point{}, draw.Src)
+		vertical := image.Rect(x2-half, min(y1, y2)-half, x2+thickness-half, max(y1, y2)+thickness-half)
+		draw.Draw(img, vertical, pathColor, image.Point{}, draw.Src)
+	}
+}
+
+// solutionThickness returns the width of path overlay lines, defaulting to the wall thickness
+func (r *Renderer) solutionThickness() int {
+	if r.config.SolutionThickness > 0 {
+		return r.config.SolutionThickness
+	}
+	return r.config.WallThickness
+}
+
+// drawArrowheads draws a filled arrowhead every SolutionArrowSpacing cells along
+// the path. Each arrow sits on the boundary between two consecutive cells and
+// points along the step between them, toward the end of the path.
+func (r *Renderer) drawArrowheads(img *image.RGBA, path []Point, c color.Color) {
+	spacing := r.config.SolutionArrowSpacing
+	if spacing <= 0 {
+		return
+	}
+
+	// Size the arrow to the corridor, but keep it wider than the line beneath it
+	length := float64(min(r.cellWidth(), r.cellHeight())) * 2 / 5
+	halfWidth := max(length/2, float64(r.solutionThickness()))
+
+	for i := spacing; i < len(path); i += spacing {
+		x1, y1 := r.cellCenter(path[i-1])
+		x2, y2 := r.cellCenter(path[i])
+		dx, dy := float64(x2-x1), float64(y2-y1)
+		distance := math.Hypot(dx, dy)
+		if distance == 0 {
+			continue
+		}
+
+		// Unit vectors along the step and across it
+		ux, uy := dx/distance, dy/distance
+		nx, ny := -uy, ux
+		midX, midY := float64(x1+x2)/2, float64(y1+y2)/2
+
+		tip := [2]float64{midX + ux*length/2, midY + uy*length/2}
+		baseX, baseY := midX-ux*length/2, midY-uy*length/2
+		left := [2]float64{baseX + nx*halfWidth, baseY + ny*halfWidth}
+		right := [2]float64{baseX - nx*halfWidth, baseY - ny*halfWidth}
+		fillConvexPolygon(img, [][2]float64{tip, left, right}, c)
+	}
+}
+
+// fillConvexPolygon fills every pixel whose center lies inside the convex polygon.
+// Vertices may run in either direction.
+func fillConvexPolygon(img *image.RGBA, vertices [][2]float64, c color.Color) {
+	minX, minY := vertices[0][0], vertices[0][1]
+	maxX, maxY := minX, minY
+	for _, v := range vertices {
+		minX, maxX = min(minX, v[0]), max(maxX, v[0])
+		minY, maxY = min(minY, v[1]), max(maxY, v[1])
+	}
+
+	for y := int(math.Floor(minY)); y <= int(math.Ceil(maxY)); y++ {
+		for x := int(math.Floor(minX)); x <= int(math.Ceil(maxX)); x++ {
+			px, py := float64(x)+0.5, float64(y)+0.5
+
+			// Inside when the pixel is on the same side of every edge
+			positive, negative := false, false
+			for i, a := range vertices {
+				b := vertices[(i+1)%len(vertices)]
+				cross := (b[0]-a[0])*(py-a[1]) - (b[1]-a[1])*(px-a[0])
+				positive = positive || cross > 0
+				negative = negative || cross < 0
+			}
+			if !(positive && negative) && image.Pt(x, y).In(img.Bounds()) {
+				img.Set(x, y, c)
+			}
+		}
+	}
+}
+
+// writePNG encodes the image to a PNG file, wrapping any failure in a RenderError
+func (r *Renderer) writePNG(img image.Image, filename string) error {
 	file, err := os.Create(filename)
 	if err != nil {
-		return err
+		return renderError(filename, err)
 	}
 	defer file.Close()
 
-	return png.Encode(file, img)
+	return renderError(filename, r.encodePNG(file, img))
+}
+
+// encodePNG writes the image as PNG data using the configured compression level
+func (r *Renderer) encodePNG(w io.Writer, img image.Image) error {
+	encoder := png.Encoder{CompressionLevel: r.config.CompressionLevel}
+	return encoder.Encode(w, img)
 }
 
 // createImage creates an image representation of the maze
-func (r *Renderer) createImage(maze *Maze) image.Image {
-	// Calculate image dimensions based on maze size, cell size, padding, and header
-	imgWidth := maze.Width*r.config.CellSize + r.config.WallThickness + 2*r.config.Padding
-	imgHeight := maze.Height*r.config.CellSize + r.config.WallThickness + 2*r.config.Padding + r.config.HeaderHeight
+func (r *Renderer) createImage(maze *Maze) *image.RGBA {
+	return r.createLayeredImage(maze, nil)
+}
+
+// createLayeredImage creates an image of the maze, calling underlay (if non-nil)
+// after the background is filled but before walls and markers are drawn
+func (r *Renderer) createLayeredImage(maze *Maze, underlay func(img *image.RGBA)) *image.RGBA {
+	// Calculate image dimensions based on maze size, cell size, padding, header, and footer
+	imgWidth, imgHeight := r.GetImageDimensions(maze)
 
-	// Create image with white background (paths)
 	img := image.NewRGBA(image.Rect(0, 0, imgWidth, imgHeight))
-	draw.Draw(img, img.Bounds(), &image.Uniform{r.config.PathColor}, image.Point{}, draw.Src)
+	r.fillBackground(img, r.mazeBounds(maze))
 
-	// Draw legend in header area
-	r.drawLegend(img)
+	// Draw anything that belongs beneath the walls
+	if underlay != nil {
+		underlay(img)
+	}
+
+	// Shade the solution corridor over the background but beneath the walls,
+	// through a mask so the overlapping cell edges aren't blended twice
+	if r.config.ShadeSolution {
+		mask := image.NewAlpha(img.Bounds())
+		for _, pos := range NewValidator().FindPath(maze) {
+			draw.Draw(mask, r.cellRect(pos), image.Opaque, image.Point{}, draw.Src)
+		}
+		draw.DrawMask(img, img.Bounds(), &image.Uniform{r.shadeColor()}, image.Point{}, mask, image.Point{}, draw.Over)
+	}
+
+	// Draw the guide grid beneath the walls so they stay crisp
+	if r.config.ShowGrid {
+		gridColor := &image.Uniform{r.gridColor()}
+		for _, line := range r.gridLines(maze) {
+			draw.Draw(img, line, gridColor, image.Point{}, draw.Src)
+		}
+	}
 
 	// Draw walls (offset by header height)
 	r.drawWalls(img, maze)
+	if maze.Diagonal {
+		r.drawDiagonalPassages(img, maze)
+	}
+	if r.config.SolutionWallColor != nil {
+		r.drawSolutionWalls(img, maze)
+	}
+
+	// Label each cell with its coordinates if enabled
+	if r.config.ShowCoordinates {
+		r.drawCoordinates(img, maze)
+	}
 
 	// Draw start and finish markers (offset by header height)
 	r.drawMarkers(img, maze)
 
+	// Draw scale bar in footer area if enabled
+	if r.config.ScaleBar {
+		r.drawScaleBar(img, maze)
+	}
+
 	return img
 }
 
+// fillBackground fills the image with white background (paths), or the gradient
+// if configured, then fills the header and draws the legend. With a border color
+// the background only covers mazeArea and the rest takes the border color.
+func (r *Renderer) fillBackground(img *image.RGBA, mazeArea image.Rectangle) {
+	background := img.Bounds()
+	if r.config.BorderColor != nil {
+		draw.Draw(img, img.Bounds(), &image.Uniform{r.config.BorderColor}, image.Point{}, draw.Src)
+		background = mazeArea
+	}
+	if r.config.BackgroundGradient != nil {
+		r.drawBackgroundGradient(img, background)
+	} else {
+		draw.Draw(img, background, &image.Uniform{r.config.PathColor}, image.Point{}, draw.Src)
+	}
+
+	// Fill header background, defaulting to the border or path color; a
+	// gradient background continues behind the header unless HeaderColor is set
+	if headerColor := r.headerColor(); headerColor != nil {
+		header := image.Rect(0, 0, img.Bounds().Dx(), r.headerHeight())
+		draw.Draw(img, header, &image.Uniform{headerColor}, image.Point{}, draw.Src)
+	}
+
+	// Draw legend in header area
+	r.drawLegend(img)
+}
+
+// headerColor returns the background color of the legend header: HeaderColor if set,
+// otherwise BorderColor, otherwise PathColor unless a background gradient shows through
+func (r *Renderer) headerColor() color.Color {
+	switch {
+	case r.config.HeaderColor != nil:
+		return r.config.HeaderColor
+	case r.config.BorderColor != nil:
+		return r.config.BorderColor
+	case r.config.BackgroundGradient != nil:
+		return nil
+	}
+	return r.config.PathColor
+}
+
+// drawBackgroundGradient fills the bounds with a vertical blend between the two
+// BackgroundGradient colors, one row at a time
+func (r *Renderer) drawBackgroundGradient(img *image.RGBA, bounds image.Rectangle) {
+	top, bottom := r.config.BackgroundGradient[0], r.config.BackgroundGradient[1]
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		t := 0.0
+		if bounds.Dy() > 1 {
+			t = float64(y-bounds.Min.Y) / float64(bounds.Dy()-1)
+		}
+		row := image.Rect(bounds.Min.X, y, bounds.Max.X, y+1)
+		draw.Draw(img, row, &image.Uniform{lerpColor(top, bottom, t)}, image.Point{}, draw.Src)
+	}
+}
+
 // drawLegend draws the legend in the header area
 func (r *Renderer) drawLegend(img *image.RGBA) {
+	if !r.config.ShowLegend {
+		return
+	}
+
 	// Legend text - use ASCII alternatives if Unicode font is not available
-	var legendText string
-	if r.fontFace == basicfont.Face7x13 {
-		// Fallback to ASCII symbols that work with basic font
-		legendText = "O START    # FINISH"
-	} else {
-		// Use Unicode symbols with TrueType font
-		legendText = "○ START    ■ FINISH"
+	legendText := r.legendText(r.fontFace != basicfont.Face7x13)
+
+	// Use scaled font rendering, centered in the header
+	header := image.Rect(0, 0, img.Bounds().Max.X, r.headerHeight())
+	r.drawScaledText(img, legendText, r.config.LegendFontSize, header)
+}
+
+// legendText returns the legend describing the configured markers, with Unicode or ASCII symbols
+func (r *Renderer) legendText(unicode bool) string {
+	return fmt.Sprintf("%s START    %s FINISH",
+		markerSymbol(r.config.StartMarker, unicode), markerSymbol(r.config.FinishMarker, unicode))
+}
+
+// markerSymbols maps each marker shape to its ASCII and Unicode legend symbols
+var markerSymbols = map[MarkerShape][2]string{
+	Circle:   {"O", "○"},
+	Square:   {"#", "■"},
+	Triangle: {"^", "△"},
+	Cross:    {"X", "✕"},
+	Diamond:  {"<>", "◇"},
+}
+
+// markerSymbol returns the legend symbol for a marker shape
+func markerSymbol(shape MarkerShape, unicode bool) string {
+	symbol, ok := markerSymbols[shape]
+	if !ok {
+		symbol = markerSymbols[Circle]
 	}
+	if unicode {
+		return symbol[1]
+	}
+	return symbol[0]
+}
 
-	// Use scaled font rendering
-	r.drawScaledText(img, legendText, r.config.LegendFontSize)
+// drawScaledText draws text with a specified scale factor, centered in the given area.
+// TrueType faces are already loaded at the target size and are drawn directly;
+// the basic font is enlarged by pixel-block scaling.
+func (r *Renderer) drawScaledText(img *image.RGBA, text string, scale int, area image.Rectangle) {
+	if r.fontFace != basicfont.Face7x13 {
+		r.drawFontText(img, text, area)
+		return
+	}
+	r.drawBasicText(img, text, scale, area)
 }
 
-// drawScaledText draws text with a specified scale factor
-func (r *Renderer) drawScaledText(img *image.RGBA, text string, scale int) {
+// drawBasicText draws text in the basic font enlarged by pixel-block scaling, centered in the given area
+func (r *Renderer) drawBasicText(img *image.RGBA, text string, scale int, area image.Rectangle) {
 	// Create a temporary image for the original font
 	d := &font.Drawer{
 		Dst:  image.NewRGBA(image.Rect(0, 0, 1000, 100)), // Temporary canvas
@@ -109,11 +423,11 @@ func (r *Renderer) drawScaledText(img *image.RGBA, text string, scale int) {
 		}
 	}
 
-	// Draw text on temporary image
+	// Draw text on temporary image with the top of the glyphs at row 5
 	d.Dst = tempImg
 	d.Dot = fixed.Point26_6{
-		X: fixed.I(10),
-		Y: fixed.I(origHeight + 5),
+		X: fixed.I(10) - textBounds.Min.X,
+		Y: fixed.I(5) - textBounds.Min.Y,
 	}
 	d.DrawString(text)
 
@@ -121,9 +435,9 @@ func (r *Renderer) drawScaledText(img *image.RGBA, text string, scale int) {
 	scaledWidth := origWidth * scale
 	scaledHeight := origHeight * scale
 
-	// Calculate position to center the scaled text in header
-	textX := (img.Bounds().Max.X - scaledWidth) / 2
-	textY := (r.config.HeaderHeight - scaledHeight) / 2
+	// Calculate position to center the scaled text in the area
+	textX := area.Min.X + (area.Dx()-scaledWidth)/2
+	textY := area.Min.Y + (area.Dy()-scaledHeight)/2
 
 	// Draw scaled text by copying each pixel as a scale x scale block
 	for y := 0; y < origHeight; y++ {
@@ -150,8 +464,50 @@ func (r *Renderer) drawScaledText(img *image.RGBA, text string, scale int) {
 	}
 }
 
+// drawCoordinates labels every cell with its (x,y) position in small unscaled text
+func (r *Renderer) drawCoordinates(img *image.RGBA, maze *Maze) {
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			if maze.Cells[y][x].Disabled {
+				continue
+			}
+			label := fmt.Sprintf("(%d,%d)", x, y)
+			r.drawBasicText(img, label, 1, r.cellRect(Point{x, y}))
+		}
+	}
+}
+
+// drawFontText draws anti-aliased text with the loaded font face, centered in the given area
+func (r *Renderer) drawFontText(img *image.RGBA, text string, area image.Rectangle) {
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(r.config.TextColor),
+		Face: r.fontFace,
+	}
+
+	// Center horizontally on the advance width and vertically on the face's line metrics
+	metrics := r.fontFace.Metrics()
+	textWidth := d.MeasureString(text)
+	lineHeight := metrics.Ascent + metrics.Descent
+
+	d.Dot = fixed.Point26_6{
+		X: fixed.I(area.Min.X) + (fixed.I(area.Dx())-textWidth)/2,
+		Y: fixed.I(area.Min.Y) + (fixed.I(area.Dy())-lineHeight)/2 + metrics.Ascent,
+	}
+	d.DrawString(text)
+}
+
 // drawWalls draws all the walls in the maze
 func (r *Renderer) drawWalls(img *image.RGBA, maze *Maze) {
+	if r.config.HandDrawn {
+		r.drawHandDrawnWalls(img, maze, r.config.WallColor, nil)
+		return
+	}
+	if r.config.RoundedCorners {
+		r.drawRoundedWalls(img, maze)
+		return
+	}
+
 	wallColor := &image.Uniform{r.config.WallColor}
 
 	for y := 0; y < maze.Height; y++ {
@@ -161,88 +517,446 @@ func (r *Renderer) drawWalls(img *image.RGBA, maze *Maze) {
 				continue
 			}
 
-			// Calculate cell position in pixels (offset by padding and header)
-			cellX := x*r.config.CellSize + r.config.Padding
-			cellY := y*r.config.CellSize + r.config.Padding + r.config.HeaderHeight
+			// Draw walls for this cell, but skip outer walls for start/finish positions
+			for _, dir := range AllDirections() {
+				if r.wallVisible(maze, cell, dir) {
+					draw.Draw(img, r.wallRect(x, y, dir), wallColor, image.Point{}, draw.Src)
+				}
+			}
+		}
+	}
+}
 
-			// Check if this cell is the start or finish position
-			isStart := (x == maze.Start.X && y == maze.Start.Y)
-			isFinish := (x == maze.Finish.X && y == maze.Finish.Y)
+// drawRoundedWalls draws the walls with softened joints. Each wall is drawn
+// without the square post at either end, and every post is then filled
+// according to the walls meeting there: free ends get a semicircular cap, the
+// outside of an L-shaped corner is rounded with a radius of one wall
+// thickness, and straight runs, T-junctions, and crossings stay square.
+func (r *Renderer) drawRoundedWalls(img *image.RGBA, maze *Maze) {
+	wallColor := &image.Uniform{r.config.WallColor}
+	thickness := r.config.WallThickness
 
-			// Draw walls for this cell, but skip outer walls for start/finish positions
-			if cell.Walls[North] {
-				// Skip drawing north wall if this is start/finish on top edge
-				if !((isStart || isFinish) && y == 0) {
-					r.drawHorizontalWall(img, wallColor, cellX, cellY, r.config.CellSize)
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+			for _, dir := range AllDirections() {
+				if !r.wallVisible(maze, cell, dir) {
+					continue
 				}
+
+				// Trim the posts off both ends of the wall
+				rect := r.wallRect(x, y, dir)
+				if dir == North || dir == South {
+					rect.Min.X += thickness
+					rect.Max.X -= thickness
+				} else {
+					rect.Min.Y += thickness
+					rect.Max.Y -= thickness
+				}
+				draw.Draw(img, rect, wallColor, image.Point{}, draw.Src)
+			}
+		}
+	}
+
+	for y := 0; y <= maze.Height; y++ {
+		for x := 0; x <= maze.Width; x++ {
+			r.drawPost(img, x, y, r.postArms(maze, x, y))
+		}
+	}
+}
+
+// postArms reports, for each direction, whether a visible wall leaves the
+// post at the top-left corner of cell (x, y) in that direction
+func (r *Renderer) postArms(maze *Maze, x, y int) [4]bool {
+	// A wall counts if either cell beside it draws it
+	drawn := func(cx, cy int, dir Direction) bool {
+		cell := maze.GetCell(cx, cy)
+		return cell != nil && r.wallVisible(maze, cell, dir)
+	}
+
+	var arms [4]bool
+	arms[North] = drawn(x-1, y-1, East) || drawn(x, y-1, West)
+	arms[South] = drawn(x-1, y, East) || drawn(x, y, West)
+	arms[West] = drawn(x-1, y-1, South) || drawn(x-1, y, North)
+	arms[East] = drawn(x, y-1, South) || drawn(x, y, North)
+	return arms
+}
+
+// drawPost fills the wall-thickness square at the top-left corner of cell (x, y),
+// shaped by the walls that meet there
+func (r *Renderer) drawPost(img *image.RGBA, x, y int, arms [4]bool) {
+	var dirs []Direction
+	for _, dir := range AllDirections() {
+		if arms[dir] {
+			dirs = append(dirs, dir)
+		}
+	}
+	if len(dirs) == 0 {
+		return
+	}
+
+	// Unit vectors pointing along each arm in screen coordinates
+	vectors := [4][2]float64{North: {0, -1}, East: {1, 0}, South: {0, 1}, West: {-1, 0}}
+
+	thickness := r.config.WallThickness
+	half := float64(thickness) / 2
+	left := x*r.cellWidth() + r.config.Padding
+	top := y*r.cellHeight() + r.config.Padding + r.headerHeight()
+
+	for dy := 0; dy < thickness; dy++ {
+		for dx := 0; dx < thickness; dx++ {
+			// Pixel center relative to the center of the post
+			fx, fy := float64(dx)+0.5-half, float64(dy)+0.5-half
+
+			inside := true
+			switch {
+			case len(dirs) == 1:
+				// Semicircular cap: the half facing the arm plus a disc around the center
+				v := vectors[dirs[0]]
+				inside = fx*v[0]+fy*v[1] >= 0 || math.Hypot(fx, fy) <= half
+			case len(dirs) == 2 && dirs[0].Opposite() != dirs[1]:
+				// L-shaped corner: round the outside about the post's inner corner
+				a, b := vectors[dirs[0]], vectors[dirs[1]]
+				cornerX, cornerY := (a[0]+b[0])*half, (a[1]+b[1])*half
+				inside = math.Hypot(fx-cornerX, fy-cornerY) <= float64(thickness)
+			}
+
+			if inside && image.Pt(left+dx, top+dy).In(img.Bounds()) {
+				img.Set(left+dx, top+dy, r.config.WallColor)
 			}
-			if cell.Walls[South] {
-				// Skip drawing south wall if this is start/finish on bottom edge
-				if !((isStart || isFinish) && y == maze.Height-1) {
-					r.drawHorizontalWall(img, wallColor, cellX, cellY+r.config.CellSize, r.config.CellSize)
+		}
+	}
+}
+
+// drawSolutionWalls redraws every visible wall of the cells on the solution
+// path in SolutionWallColor, outlining the corridor from start to finish
+func (r *Renderer) drawSolutionWalls(img *image.RGBA, maze *Maze) {
+	if r.config.HandDrawn {
+		r.drawHandDrawnWalls(img, maze, r.config.SolutionWallColor, NewValidator().FindPath(maze))
+		return
+	}
+
+	wallColor := &image.Uniform{r.config.SolutionWallColor}
+	for _, pos := range NewValidator().FindPath(maze) {
+		cell := maze.GetCell(pos.X, pos.Y)
+		for _, dir := range AllDirections() {
+			if r.wallVisible(maze, cell, dir) {
+				draw.Draw(img, r.wallRect(pos.X, pos.Y, dir), wallColor, image.Point{}, draw.Src)
+			}
+		}
+	}
+}
+
+// drawHandDrawnWalls draws the visible walls of the given cells, or of every
+// cell when cells is nil, as wobbly strokes in color c. Each grid corner is
+// nudged by a pseudo-random offset so the walls meeting there stay joined, and
+// each wall bows through a nudged midpoint. The offsets are hashed from grid
+// coordinates, so the same maze always renders the same way.
+func (r *Renderer) drawHandDrawnWalls(img *image.RGBA, maze *Maze, c color.Color, cells []Point) {
+	if cells == nil {
+		for y := 0; y < maze.Height; y++ {
+			for x := 0; x < maze.Width; x++ {
+				cells = append(cells, Point{x, y})
+			}
+		}
+	}
+
+	jitter := float64(r.config.WallThickness) / 2
+	if r.config.HandDrawnJitter > 0 {
+		jitter = float64(r.config.HandDrawnJitter)
+	}
+	radius := max(r.config.WallThickness/2, 1)
+	half := float64(r.config.WallThickness) / 2
+
+	// point returns the pixel position of a nudged point in doubled grid
+	// coordinates, where even values are corners and odd values are midpoints
+	point := func(gx, gy int) (float64, float64) {
+		x := float64(gx*r.cellWidth())/2 + float64(r.config.Padding) + half
+		y := float64(gy*r.cellHeight())/2 + float64(r.config.Padding+r.headerHeight()) + half
+		return x + jitter*wobble(gx, gy, 0), y + jitter*wobble(gx, gy, 1)
+	}
+
+	for _, pos := range cells {
+		cell := maze.GetCell(pos.X, pos.Y)
+		for _, dir := range AllDirections() {
+			if !r.wallVisible(maze, cell, dir) {
+				continue
+			}
+
+			// Corners at either end of the wall, in doubled grid coordinates
+			ax, ay := 2*pos.X, 2*pos.Y
+			bx, by := ax, ay
+			switch dir {
+			case North:
+				bx += 2
+			case South:
+				ay, by = ay+2, ay+2
+				bx += 2
+			case West:
+				by += 2
+			case East:
+				ax, bx = ax+2, ax+2
+				by += 2
+			}
+
+			x1, y1 := point(ax, ay)
+			midX, midY := point((ax+bx)/2, (ay+by)/2)
+			x2, y2 := point(bx, by)
+			for _, segment := range [][4]float64{{x1, y1, midX, midY}, {midX, midY, x2, y2}} {
+				steps := max(int(math.Hypot(segment[2]-segment[0], segment[3]-segment[1])), 1)
+				for i := 0; i <= steps; i++ {
+					t := float64(i) / float64(steps)
+					x := segment[0] + (segment[2]-segment[0])*t
+					y := segment[1] + (segment[3]-segment[1])*t
+					r.drawFilledCircle(img, int(math.Round(x)), int(math.Round(y)), radius, c)
 				}
 			}
-			if cell.Walls[West] {
-				// Skip drawing west wall if this is start/finish on left edge
-				if !((isStart || isFinish) && x == 0) {
-					r.drawVerticalWall(img, wallColor, cellX, cellY, r.config.CellSize)
+		}
+	}
+}
+
+// wobble returns a pseudo-random value from -1 to 1 for one axis of a grid
+// point, mixing the coordinates with a 64-bit finalizer so nearby points differ
+func wobble(x, y, axis int) float64 {
+	h := uint64(uint32(x))<<32 | uint64(uint32(y))
+	h ^= uint64(axis+1) * 0x9e3779b97f4a7c15
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return float64(h>>11)/float64(1<<52) - 1
+}
+
+// drawDiagonalPassages opens the corners crossed by diagonal passages. The wall
+// ends around each such corner are cleared to the path color, and a diagonal
+// wall segment is drawn across the corner of each of the two cells the passage
+// runs between, leaving a slanted corridor through the corner. PNG output only.
+func (r *Renderer) drawDiagonalPassages(img *image.RGBA, maze *Maze) {
+	pathColor := &image.Uniform{r.config.PathColor}
+	gap := float64(min(r.cellWidth(), r.cellHeight())) * 2 / 5
+	half := float64(r.config.WallThickness) / 2
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+
+			// Each interior corner is reached by a south-east or south-west passage
+			for _, dir := range []Direction{SouthEast, SouthWest} {
+				if maze.GetNeighbor(cell, dir) == nil || cell.HasWall(dir) {
+					continue
+				}
+
+				// Center of the wall post at the shared corner
+				dx, dy := dir.Offset()
+				cornerX := float64(max(x+dx, x)*r.cellWidth()+r.config.Padding) + half
+				cornerY := float64((y+1)*r.cellHeight()+r.config.Padding+r.headerHeight()) + half
+
+				clear := image.Rect(int(cornerX-gap), int(cornerY-gap), int(cornerX+gap), int(cornerY+gap))
+				draw.Draw(img, clear, pathColor, image.Point{}, draw.Src)
+
+				// Cut off the corners of the two cells the passage does not join
+				for _, side := range []float64{1, -1} {
+					sx, sy := side*float64(dx), -side*float64(dy)
+					r.drawLine(img, cornerX, cornerY+sy*gap, cornerX+sx*gap, cornerY)
 				}
 			}
-			if cell.Walls[East] {
-				// Skip drawing east wall if this is start/finish on right edge
-				if !((isStart || isFinish) && x == maze.Width-1) {
-					r.drawVerticalWall(img, wallColor, cellX+r.config.CellSize, cellY, r.config.CellSize)
+		}
+	}
+}
+
+// wallVisible reports whether the wall on the given side of a cell should be drawn.
+// Outer walls are left open where the start or finish sits on the maze edge,
+// and disabled cells are not drawn at all.
+func (r *Renderer) wallVisible(maze *Maze, cell *Cell, dir Direction) bool {
+	return !cell.Disabled && cell.HasWall(dir) && !isEdgeOpening(maze, cell, dir)
+}
+
+// wallRect returns the pixel rectangle covered by the wall on the given side of a cell
+func (r *Renderer) wallRect(x, y int, dir Direction) image.Rectangle {
+	// Calculate cell position in pixels (offset by padding and header)
+	cellX := x*r.cellWidth() + r.config.Padding
+	cellY := y*r.cellHeight() + r.config.Padding + r.headerHeight()
+	width, height := r.cellWidth(), r.cellHeight()
+	thickness := r.config.WallThickness
+
+	switch dir {
+	case North:
+		return image.Rect(cellX, cellY, cellX+width+thickness, cellY+thickness)
+	case South:
+		return image.Rect(cellX, cellY+height, cellX+width+thickness, cellY+height+thickness)
+	case West:
+		return image.Rect(cellX, cellY, cellX+thickness, cellY+height+thickness)
+	case East:
+		return image.Rect(cellX+width, cellY, cellX+width+thickness, cellY+height+thickness)
+	}
+	return image.Rectangle{}
+}
+
+// gridLines returns thin lines along every cell boundary, centered in the wall positions.
+// Masked mazes get lines around each enabled cell instead, so the shape's outside stays blank.
+func (r *Renderer) gridLines(maze *Maze) []image.Rectangle {
+	width, height := r.cellWidth(), r.cellHeight()
+	thickness := max(r.config.WallThickness/4, 1)
+	inset := (r.config.WallThickness - thickness) / 2
+
+	left := r.config.Padding
+	top := r.config.Padding + r.headerHeight()
+
+	if maze.isMasked() {
+		var lines []image.Rectangle
+		for y := 0; y < maze.Height; y++ {
+			for x := 0; x < maze.Width; x++ {
+				if maze.Cells[y][x].Disabled {
+					continue
 				}
+				cellX, cellY := left+x*width, top+y*height
+				lines = append(lines,
+					image.Rect(cellX, cellY+inset, cellX+width+r.config.WallThickness, cellY+inset+thickness),
+					image.Rect(cellX, cellY+height+inset, cellX+width+r.config.WallThickness, cellY+height+inset+thickness),
+					image.Rect(cellX+inset, cellY, cellX+inset+thickness, cellY+height+r.config.WallThickness),
+					image.Rect(cellX+width+inset, cellY, cellX+width+inset+thickness, cellY+height+r.config.WallThickness))
 			}
 		}
+		return lines
 	}
+
+	right := left + maze.Width*width + r.config.WallThickness
+	bottom := top + maze.Height*height + r.config.WallThickness
+
+	var lines []image.Rectangle
+	for x := 0; x <= maze.Width; x++ {
+		lineX := left + x*width + inset
+		lines = append(lines, image.Rect(lineX, top, lineX+thickness, bottom))
+	}
+	for y := 0; y <= maze.Height; y++ {
+		lineY := top + y*height + inset
+		lines = append(lines, image.Rect(left, lineY, right, lineY+thickness))
+	}
+	return lines
 }
 
-// drawHorizontalWall draws a horizontal wall
-func (r *Renderer) drawHorizontalWall(img *image.RGBA, wallColor *image.Uniform, x, y, length int) {
-	rect := image.Rect(x, y, x+length+r.config.WallThickness, y+r.config.WallThickness)
-	draw.Draw(img, rect, wallColor, image.Point{}, draw.Src)
+// gridColor returns the configured grid color, falling back to the default
+func (r *Renderer) gridColor() color.Color {
+	if r.config.GridColor == nil {
+		return DefaultRenderConfig().GridColor
+	}
+	return r.config.GridColor
 }
 
-// drawVerticalWall draws a vertical wall
-func (r *Renderer) drawVerticalWall(img *image.RGBA, wallColor *image.Uniform, x, y, length int) {
-	rect := image.Rect(x, y, x+r.config.WallThickness, y+length+r.config.WallThickness)
-	draw.Draw(img, rect, wallColor, image.Point{}, draw.Src)
+// shadeColor returns the configured solution shade color, falling back to the default
+func (r *Renderer) shadeColor() color.Color {
+	if r.config.ShadeColor == nil {
+		return DefaultRenderConfig().ShadeColor
+	}
+	return r.config.ShadeColor
 }
 
 // drawMarkers draws the start and finish markers
 func (r *Renderer) drawMarkers(img *image.RGBA, maze *Maze) {
-	// Draw start marker (circle)
-	r.drawCircleMarker(img, maze.Start)
+	startX, startY := r.cellCenter(maze.Start)
+	r.drawMarkerAt(img, r.config.StartMarker, startX, startY)
+	r.drawMarkerLabel(img, maze, maze.Start, r.config.StartLabel)
 
-	// Draw finish marker (square)
-	r.drawSquareMarker(img, maze.Finish)
+	for _, finish := range maze.AllFinishes() {
+		finishX, finishY := r.cellCenter(finish)
+		r.drawMarkerAt(img, r.config.FinishMarker, finishX, finishY)
+		r.drawMarkerLabel(img, maze, finish, r.config.FinishLabel)
+	}
 }
 
-// drawCircleMarker draws a circle marker in the center of the specified cell
-func (r *Renderer) drawCircleMarker(img *image.RGBA, pos Point) {
-	// Calculate cell center position (offset by padding and header)
-	cellX := pos.X*r.config.CellSize + r.config.Padding
-	cellY := pos.Y*r.config.CellSize + r.config.Padding + r.config.HeaderHeight
-	centerX := cellX + r.config.CellSize/2
-	centerY := cellY + r.config.CellSize/2
+// drawMarkerLabel draws a text label for the marker in the given cell. Cells on
+// the edge of the maze are labeled in the padding beside them, preferring the
+// left and right sides; other cells are labeled in the strip of the cell below
+// the marker. The basic font is enlarged up to the legend scale while the text
+// still fits. Text that does not fit even unscaled grows its area around the
+// same center, kept inside the image, so it may cover neighboring walls.
+func (r *Renderer) drawMarkerLabel(img *image.RGBA, maze *Maze, pos Point, label string) {
+	if label == "" {
+		return
+	}
 
-	// Circle radius (about 1/3 of cell size)
-	radius := r.config.CellSize / 3
-	thickness := 3 // Line thickness
+	cell := r.cellRect(pos)
+	bounds := r.mazeBounds(maze)
+	_, centerY := r.cellCenter(pos)
+	_, radiusY := r.markerRadii()
+	below := centerY + radiusY + 1
+	area := image.Rect(cell.Min.X, below, cell.Max.X, max(cell.Max.Y, below+basicFontHeight))
+	switch {
+	case pos.X == 0 && r.config.Padding > 0:
+		area = image.Rect(0, cell.Min.Y, bounds.Min.X, cell.Max.Y)
+	case pos.X == maze.Width-1 && r.config.Padding > 0:
+		area = image.Rect(bounds.Max.X, cell.Min.Y, img.Bounds().Max.X, cell.Max.Y)
+	case pos.Y == 0 && r.config.Padding > 0:
+		area = image.Rect(cell.Min.X, bounds.Min.Y-r.config.Padding, cell.Max.X, bounds.Min.Y)
+	case pos.Y == maze.Height-1 && r.config.Padding > 0:
+		area = image.Rect(cell.Min.X, bounds.Max.Y, cell.Max.X, bounds.Max.Y+r.config.Padding)
+	}
 
-	// Draw circle outline
-	for y := centerY - radius; y <= centerY+radius; y++ {
-		for x := centerX - radius; x <= centerX+radius; x++ {
-			// Calculate distance from center
+	// Shrink to the largest scale that fits, but never below the unscaled font
+	width := font.MeasureString(basicfont.Face7x13, label).Ceil()
+	area = growWithin(area, width, basicFontHeight, img.Bounds())
+	scale := min(r.config.LegendFontSize, area.Dx()/max(width, 1), area.Dy()/basicFontHeight)
+	r.drawBasicText(img, label, max(scale, 1), area)
+}
+
+// growWithin enlarges area around its center to at least width by height,
+// then shifts it to lie inside bounds where it fits
+func growWithin(area image.Rectangle, width, height int, bounds image.Rectangle) image.Rectangle {
+	if grow := width - area.Dx(); grow > 0 {
+		area.Min.X -= grow / 2
+		area.Max.X += grow - grow/2
+	}
+	if grow := height - area.Dy(); grow > 0 {
+		area.Min.Y -= grow / 2
+		area.Max.Y += grow - grow/2
+	}
+
+	shift := image.Point{
+		X: max(bounds.Min.X-area.Min.X, 0) + min(bounds.Max.X-area.Max.X, 0),
+		Y: max(bounds.Min.Y-area.Min.Y, 0) + min(bounds.Max.Y-area.Max.Y, 0),
+	}
+	return area.Add(shift)
+}
+
+// drawMarkerAt draws a marker of the given shape centered at the given pixel position
+func (r *Renderer) drawMarkerAt(img *image.RGBA, shape MarkerShape, centerX, centerY int) {
+	switch shape {
+	case Square:
+		r.drawSquareMarkerAt(img, centerX, centerY)
+	case Triangle, Diamond:
+		radiusX, radiusY := r.markerRadii()
+		r.drawPolygonMarkerAt(img, markerPolygon(shape, float64(centerX), float64(centerY), float64(radiusX), float64(radiusY)))
+	case Cross:
+		r.drawCrossMarkerAt(img, centerX, centerY)
+	default:
+		r.drawCircleMarkerAt(img, centerX, centerY)
+	}
+}
+
+// drawCircleMarkerAt draws a circle marker centered at the given pixel position.
+// On rectangular cells the circle is stretched into an ellipse matching the cell aspect.
+func (r *Renderer) drawCircleMarkerAt(img *image.RGBA, centerX, centerY int) {
+	if r.config.AntialiasMarkers {
+		r.drawSmoothCircleMarkerAt(img, centerX, centerY)
+		return
+	}
+
+	// Radii (about 1/3 of the cell size on each axis)
+	radiusX, radiusY := r.markerRadii()
+	innerX, innerY := radiusX-markerThickness, radiusY-markerThickness
+
+	// Draw ellipse outline
+	for y := centerY - radiusY; y <= centerY+radiusY; y++ {
+		for x := centerX - radiusX; x <= centerX+radiusX; x++ {
+			// Compare (dx/rx)^2 + (dy/ry)^2 against 1 in integers, scaled by rx^2*ry^2
 			dx := x - centerX
 			dy := y - centerY
-			distSq := dx*dx + dy*dy
-			radiusSq := radius * radius
-			innerRadiusSq := (radius - thickness) * (radius - thickness)
+			outer := dx*dx*radiusY*radiusY + dy*dy*radiusX*radiusX
+			inner := dx*dx*innerY*innerY + dy*dy*innerX*innerX
 
-			// Draw if within the ring (between inner and outer radius)
-			if distSq <= radiusSq && distSq >= innerRadiusSq {
+			// Draw if within the ring (between inner and outer ellipse)
+			if outer <= radiusX*radiusX*radiusY*radiusY && inner >= innerX*innerX*innerY*innerY {
 				if x >= 0 && x < img.Bounds().Max.X && y >= 0 && y < img.Bounds().Max.Y {
 					img.Set(x, y, r.config.WallColor)
 				}
@@ -251,51 +965,517 @@ func (r *Renderer) drawCircleMarker(img *image.RGBA, pos Point) {
 	}
 }
 
-// drawSquareMarker draws a square marker in the center of the specified cell
-func (r *Renderer) drawSquareMarker(img *image.RGBA, pos Point) {
-	// Calculate cell center position (offset by padding and header)
-	cellX := pos.X*r.config.CellSize + r.config.Padding
-	cellY := pos.Y*r.config.CellSize + r.config.Padding + r.config.HeaderHeight
-	centerX := cellX + r.config.CellSize/2
-	centerY := cellY + r.config.CellSize/2
+// drawSquareMarkerAt draws a square marker centered at the given pixel position
+func (r *Renderer) drawSquareMarkerAt(img *image.RGBA, centerX, centerY int) {
+	if r.config.AntialiasMarkers {
+		r.drawSmoothSquareMarkerAt(img, centerX, centerY)
+		return
+	}
 
-	// Square size (about 2/3 of cell size)
-	size := r.config.CellSize * 2 / 3
-	halfSize := size / 2
-	thickness := 3 // Line thickness
+	// Square size (about 2/3 of cell size, stretched to the cell aspect)
+	halfWidth, halfHeight := r.squareMarkerHalfSize()
 
 	wallColor := &image.Uniform{r.config.WallColor}
 
 	// Draw square outline (4 rectangles for the sides)
 	// Top side
-	topRect := image.Rect(centerX-halfSize, centerY-halfSize, centerX+halfSize, centerY-halfSize+thickness)
+	topRect := image.Rect(centerX-halfWidth, centerY-halfHeight, centerX+halfWidth, centerY-halfHeight+markerThickness)
 	draw.Draw(img, topRect, wallColor, image.Point{}, draw.Src)
 
 	// Bottom side
-	bottomRect := image.Rect(centerX-halfSize, centerY+halfSize-thickness, centerX+halfSize, centerY+halfSize)
+	bottomRect := image.Rect(centerX-halfWidth, centerY+halfHeight-markerThickness, centerX+halfWidth, centerY+halfHeight)
 	draw.Draw(img, bottomRect, wallColor, image.Point{}, draw.Src)
 
 	// Left side
-	leftRect := image.Rect(centerX-halfSize, centerY-halfSize, centerX-halfSize+thickness, centerY+halfSize)
+	leftRect := image.Rect(centerX-halfWidth, centerY-halfHeight, centerX-halfWidth+markerThickness, centerY+halfHeight)
 	draw.Draw(img, leftRect, wallColor, image.Point{}, draw.Src)
 
 	// Right side
-	rightRect := image.Rect(centerX+halfSize-thickness, centerY-halfSize, centerX+halfSize, centerY+halfSize)
+	rightRect := image.Rect(centerX+halfWidth-markerThickness, centerY-halfHeight, centerX+halfWidth, centerY+halfHeight)
 	draw.Draw(img, rightRect, wallColor, image.Point{}, draw.Src)
 }
 
+// drawSmoothCircleMarkerAt draws the circle marker with anti-aliased edges.
+// Each pixel near the ring is sampled on a markerSamples grid, and the fraction
+// of samples inside the ring is blended over the pixel as wall color.
+func (r *Renderer) drawSmoothCircleMarkerAt(img *image.RGBA, centerX, centerY int) {
+	radiusX, radiusY := r.markerRadii()
+	rx, ry := float64(radiusX), float64(radiusY)
+	innerX, innerY := rx-markerThickness, ry-markerThickness
+
+	inRing := func(dx, dy float64) bool {
+		if (dx/rx)*(dx/rx)+(dy/ry)*(dy/ry) > 1 {
+			return false
+		}
+		return innerX <= 0 || innerY <= 0 || (dx/innerX)*(dx/innerX)+(dy/innerY)*(dy/innerY) >= 1
+	}
+
+	for y := centerY - radiusY - 1; y <= centerY+radiusY+1; y++ {
+		for x := centerX - radiusX - 1; x <= centerX+radiusX+1; x++ {
+			// Samples are spread across the pixel, which is centered on (x, y)
+			hits := 0
+			for sy := range markerSamples {
+				for sx := range markerSamples {
+					dx := float64(x-centerX) - 0.5 + (float64(sx)+0.5)/markerSamples
+					dy := float64(y-centerY) - 0.5 + (float64(sy)+0.5)/markerSamples
+					if inRing(dx, dy) {
+						hits++
+					}
+				}
+			}
+			blendPixel(img, x, y, r.config.WallColor, float64(hits)/(markerSamples*markerSamples))
+		}
+	}
+}
+
+// drawSmoothSquareMarkerAt draws the square marker with anti-aliased edges.
+// The outline keeps its exact fractional size of 2/3 of the cell, and each
+// pixel is covered by the area of the outer square minus the inner one.
+func (r *Renderer) drawSmoothSquareMarkerAt(img *image.RGBA, centerX, centerY int) {
+	halfWidth, halfHeight := float64(r.cellWidth())/3, float64(r.cellHeight())/3
+	cx, cy := float64(centerX), float64(centerY)
+	outerMinX, outerMaxX := cx-halfWidth, cx+halfWidth
+	outerMinY, outerMaxY := cy-halfHeight, cy+halfHeight
+	innerMinX, innerMaxX := outerMinX+markerThickness, outerMaxX-markerThickness
+	innerMinY, innerMaxY := outerMinY+markerThickness, outerMaxY-markerThickness
+
+	// overlap returns the length of [lo, hi] that falls within [a, b]
+	overlap := func(lo, hi, a, b float64) float64 {
+		return max(min(hi, b)-max(lo, a), 0)
+	}
+
+	for y := int(math.Floor(outerMinY)); y < int(math.Ceil(outerMaxY)); y++ {
+		for x := int(math.Floor(outerMinX)); x < int(math.Ceil(outerMaxX)); x++ {
+			px, py := float64(x), float64(y)
+			outer := overlap(px, px+1, outerMinX, outerMaxX) * overlap(py, py+1, outerMinY, outerMaxY)
+			inner := overlap(px, px+1, innerMinX, innerMaxX) * overlap(py, py+1, innerMinY, innerMaxY)
+			blendPixel(img, x, y, r.config.WallColor, outer-inner)
+		}
+	}
+}
+
+// blendPixel mixes c over the pixel at (x, y) with the given coverage from 0 to 1,
+// ignoring pixels outside the image
+func blendPixel(img *image.RGBA, x, y int, c color.Color, coverage float64) {
+	if coverage <= 0 || !image.Pt(x, y).In(img.Bounds()) {
+		return
+	}
+	coverage = min(coverage, 1)
+
+	src := color.RGBAModel.Convert(c).(color.RGBA)
+	dst := img.RGBAAt(x, y)
+	mix := func(s, d uint8) uint8 {
+		return uint8(math.Round(float64(d) + (float64(s)-float64(d))*coverage))
+	}
+	img.SetRGBA(x, y, color.RGBA{mix(src.R, dst.R), mix(src.G, dst.G), mix(src.B, dst.B), mix(src.A, dst.A)})
+}
+
+// drawPolygonMarkerAt draws the outline of a convex polygon marker.
+// A pixel is on the outline when its distance inside the nearest edge is under the marker thickness.
+func (r *Renderer) drawPolygonMarkerAt(img *image.RGBA, vertices [][2]float64) {
+	minX, minY := vertices[0][0], vertices[0][1]
+	maxX, maxY := minX, minY
+	for _, v := range vertices {
+		minX, maxX = min(minX, v[0]), max(maxX, v[0])
+		minY, maxY = min(minY, v[1]), max(maxY, v[1])
+	}
+
+	for y := int(math.Floor(minY)); y <= int(math.Ceil(maxY)); y++ {
+		for x := int(math.Floor(minX)); x <= int(math.Ceil(maxX)); x++ {
+			inside := math.Inf(1)
+			for i, a := range vertices {
+				b := vertices[(i+1)%len(vertices)]
+				// Vertices run clockwise on screen, so the interior is to the right of each edge
+				edgeX, edgeY := b[0]-a[0], b[1]-a[1]
+				cross := edgeX*(float64(y)-a[1]) - edgeY*(float64(x)-a[0])
+				inside = min(inside, cross/math.Hypot(edgeX, edgeY))
+			}
+			if inside >= 0 && inside < markerThickness && image.Pt(x, y).In(img.Bounds()) {
+				img.Set(x, y, r.config.WallColor)
+			}
+		}
+	}
+}
+
+// drawCrossMarkerAt draws a diagonal cross marker centered at the given pixel position
+func (r *Renderer) drawCrossMarkerAt(img *image.RGBA, centerX, centerY int) {
+	radiusX, radiusY := r.markerRadii()
+	armX := int(markerCrossArm(float64(radiusX)))
+	armY := int(markerCrossArm(float64(radiusY)))
+	half := float64(markerThickness) / 2
+	length := math.Hypot(float64(armX), float64(armY))
+
+	for dy := -armY; dy <= armY; dy++ {
+		for dx := -armX; dx <= armX; dx++ {
+			// Distance from each diagonal through the corners of the arm box
+			onFirst := math.Abs(float64(dx*armY-dy*armX))/length <= half
+			onSecond := math.Abs(float64(dx*armY+dy*armX))/length <= half
+			x, y := centerX+dx, centerY+dy
+			if (onFirst || onSecond) && image.Pt(x, y).In(img.Bounds()) {
+				img.Set(x, y, r.config.WallColor)
+			}
+		}
+	}
+}
+
+// markerPolygon returns the clockwise vertices of a triangle or diamond marker
+// inscribed in the ellipse with the given radii
+func markerPolygon(shape MarkerShape, centerX, centerY, radiusX, radiusY float64) [][2]float64 {
+	// Angles are measured clockwise from east in screen coordinates
+	angles := []float64{-90, 0, 90, 180}
+	if shape == Triangle {
+		angles = []float64{-90, 30, 150}
+	}
+
+	vertices := make([][2]float64, len(angles))
+	for i, angle := range angles {
+		rad := angle * math.Pi / 180
+		vertices[i] = [2]float64{centerX + radiusX*math.Cos(rad), centerY + radiusY*math.Sin(rad)}
+	}
+	return vertices
+}
+
+// markerRadii returns the horizontal and vertical radii of the round markers, about 1/3 of the cell
+func (r *Renderer) markerRadii() (int, int) {
+	return r.cellWidth() / 3, r.cellHeight() / 3
+}
+
+// squareMarkerHalfSize returns half the width and height of the square marker, about 2/3 of the cell
+func (r *Renderer) squareMarkerHalfSize() (int, int) {
+	return r.cellWidth() * 2 / 3 / 2, r.cellHeight() * 2 / 3 / 2
+}
+
+// markerCrossArm returns the half-length of each cross arm so its ends touch the given radius
+func markerCrossArm(radius float64) float64 {
+	return radius / math.Sqrt2
+}
+
+// RenderHeatmapToPNG renders the maze with each cell colored by its distance from the start.
+// Colors run from HeatmapNearColor at the start to HeatmapFarColor at the most
+// distant cell; unreachable cells keep the path color.
+func (r *Renderer) RenderHeatmapToPNG(maze *Maze, filename string) error {
+	r = r.fitToSize(maze)
+	distances := NewValidator().bfsDistances(maze, maze.Start)
+
+	maxDistance := 0
+	for _, d := range distances {
+		maxDistance = max(maxDistance, d)
+	}
+
+	img := r.createLayeredImage(maze, func(img *image.RGBA) {
+		for pos, d := range distances {
+			t := 0.0
+			if maxDistance > 0 {
+				t = float64(d) / float64(maxDistance)
+			}
+			fill := lerpColor(r.config.HeatmapNearColor, r.config.HeatmapFarColor, t)
+			draw.Draw(img, r.cellRect(pos), &image.Uniform{fill}, image.Point{}, draw.Src)
+		}
+	})
+
+	return r.writePNG(img, filename)
+}
+
+// RenderExplorationToPNG renders the cells visited by the HasPath search, as a
+// debugging aid. Each visited cell is tinted from HeatmapNearColor to
+// HeatmapFarColor by the order it was reached, so the flood fill's coverage is
+// visible; the search stops at the finish, and cells it never reached, such as
+// disconnected regions, keep the path color.
+func (r *Renderer) RenderExplorationToPNG(maze *Maze, filename string) error {
+	r = r.fitToSize(maze)
+
+	var order []Point
+	startCell := maze.GetCell(maze.Start.X, maze.Start.Y)
+	finishCell := maze.GetCell(maze.Finish.X, maze.Finish.Y)
+	if startCell != nil && finishCell != nil {
+		order = NewValidator().bfsVisitOrder(maze, startCell, finishCell)
+	}
+
+	img := r.createLayeredImage(maze, func(img *image.RGBA) {
+		for i, pos := range order {
+			t := 0.0
+			if len(order) > 1 {
+				t = float64(i) / float64(len(order)-1)
+			}
+			fill := lerpColor(r.config.HeatmapNearColor, r.config.HeatmapFarColor, t)
+			draw.Draw(img, r.cellRect(pos), &image.Uniform{fill}, image.Point{}, draw.Src)
+		}
+	})
+
+	return r.writePNG(img, filename)
+}
+
+// lerpColor linearly interpolates between two colors, with t in [0, 1]
+func lerpColor(from, to color.Color, t float64) color.Color {
+	r1, g1, b1, a1 := from.RGBA()
+	r2, g2, b2, a2 := to.RGBA()
+
+	mix := func(a, b uint32) uint8 {
+		return uint8((float64(a)*(1-t) + float64(b)*t) / 257)
+	}
+	return color.RGBA{mix(r1, r2), mix(g1, g2), mix(b1, b2), mix(a1, a2)}
+}
+
+// mazeBounds returns the pixel rectangle covered by the maze grid, including its outer walls
+func (r *Renderer) mazeBounds(maze *Maze) image.Rectangle {
+	left := r.config.Padding
+	top := r.config.Padding + r.headerHeight()
+	return image.Rect(left, top, left+maze.Width*r.cellWidth()+r.config.WallThickness, top+maze.Height*r.cellHeight()+r.config.WallThickness)
+}
+
+// cellRect returns the pixel rectangle covered by a cell, including its wall edges
+func (r *Renderer) cellRect(pos Point) image.Rectangle {
+	cellX := pos.X*r.cellWidth() + r.config.Padding
+	cellY := pos.Y*r.cellHeight() + r.config.Padding + r.headerHeight()
+	return image.Rect(cellX, cellY, cellX+r.cellWidth()+r.config.WallThickness, cellY+r.cellHeight()+r.config.WallThickness)
+}
+
+// cellCenter returns the pixel position of the center of the specified cell
+func (r *Renderer) cellCenter(pos Point) (int, int) {
+	cellX := pos.X*r.cellWidth() + r.config.Padding
+	cellY := pos.Y*r.cellHeight() + r.config.Padding + r.headerHeight()
+	return cellX + r.cellWidth()/2, cellY + r.cellHeight()/2
+}
+
+// cellWidth returns the width of each grid cell in pixels
+func (r *Renderer) cellWidth() int {
+	if r.config.CellWidth > 0 {
+		return r.config.CellWidth
+	}
+	return r.config.CellSize
+}
+
+// cellHeight returns the height of each grid cell in pixels
+func (r *Renderer) cellHeight() int {
+	if r.config.CellHeight > 0 {
+		return r.config.CellHeight
+	}
+	return r.config.CellSize
+}
+
+// withSquareCells returns a renderer that ignores CellWidth and CellHeight,
+// for the hex and polar layouts whose cells are sized by CellSize alone
+func (r *Renderer) withSquareCells() *Renderer {
+	if r.config.CellWidth == 0 && r.config.CellHeight == 0 {
+		return r
+	}
+	square := *r
+	square.config.CellWidth = 0
+	square.config.CellHeight = 0
+	return &square
+}
+
+// drawFilledCircle draws a solid circle centered at the given pixel position
+func (r *Renderer) drawFilledCircle(img *image.RGBA, centerX, centerY, radius int, c color.Color) {
+	radiusSq := radius * radius
+	for y := centerY - radius; y <= centerY+radius; y++ {
+		for x := centerX - radius; x <= centerX+radius; x++ {
+			dx := x - centerX
+			dy := y - centerY
+			if dx*dx+dy*dy <= radiusSq {
+				if x >= 0 && x < img.Bounds().Max.X && y >= 0 && y < img.Bounds().Max.Y {
+					img.Set(x, y, c)
+				}
+			}
+		}
+	}
+}
+
 // RenderToImage returns the maze as an image.Image (useful for further processing)
 func (r *Renderer) RenderToImage(maze *Maze) image.Image {
+	r = r.fitToSize(maze)
 	return r.createImage(maze)
 }
 
 // GetImageDimensions returns the dimensions the rendered image will have
 func (r *Renderer) GetImageDimensions(maze *Maze) (width, height int) {
-	width = maze.Width*r.config.CellSize + r.config.WallThickness + 2*r.config.Padding
-	height = maze.Height*r.config.CellSize + r.config.WallThickness + 2*r.config.Padding + r.config.HeaderHeight
+	r = r.fitToSize(maze)
+	width = maze.Width*r.cellWidth() + r.config.WallThickness + 2*r.config.Padding
+	height = maze.Height*r.cellHeight() + r.config.WallThickness + 2*r.config.Padding + r.headerHeight() + r.footerHeight()
 	return
 }
 
+// CheckRenderable returns an error if rendering the maze would produce more
+// than maxPixels pixels. It only computes dimensions and allocates nothing, so
+// callers can reject oversized requests before rendering. When possible the
+// error suggests a CellSize that fits within the budget; when ImageWidth or
+// ImageHeight is set the cell size is derived from them, so the error asks for
+// smaller bounds instead.
+func (r *Renderer) CheckRenderable(maze *Maze, maxPixels int) error {
+	bounded := r.config.ImageWidth > 0 || r.config.ImageHeight > 0
+	r = r.fitToSize(maze)
+	width, height := r.GetImageDimensions(maze)
+	if width*height <= maxPixels {
+		return nil
+	}
+	if bounded {
+		return fmt.Errorf("image of %dx%d pixels exceeds limit of %d pixels; try a smaller ImageWidth or ImageHeight",
+			width, height, maxPixels)
+	}
+
+	// Find the largest cell size that fits, keeping the cell aspect and all other settings
+	cellWidth, cellHeight := r.cellWidth(), r.cellHeight()
+	fixedWidth := width - maze.Width*cellWidth
+	fixedHeight := height - maze.Height*cellHeight
+	for w := cellWidth - 1; w > 0; w-- {
+		h := max(w*cellHeight/cellWidth, 1)
+		if (maze.Width*w+fixedWidth)*(maze.Height*h+fixedHeight) > maxPixels {
+			continue
+		}
+		if cellWidth != cellHeight {
+			return fmt.Errorf("image of %dx%d pixels exceeds limit of %d pixels; try CellWidth %d and CellHeight %d",
+				width, height, maxPixels, w, h)
+		}
+		return fmt.Errorf("image of %dx%d pixels exceeds limit of %d pixels; try CellSize %d",
+			width, height, maxPixels, w)
+	}
+
+	return fmt.Errorf("image of %dx%d pixels exceeds limit of %d pixels at any cell size",
+		width, height, maxPixels)
+}
+
+// fitToSize returns a renderer whose cell size and wall thickness are scaled so the
+// image of the maze fits within ImageWidth by ImageHeight. A zero bound leaves that
+// dimension unconstrained, and when both are zero the renderer is returned as is.
+// Walls keep their proportion to the cell width and cells keep their aspect,
+// while padding, header, and footer stay fixed.
+func (r *Renderer) fitToSize(maze *Maze) *Renderer {
+	if r.config.ImageWidth <= 0 && r.config.ImageHeight <= 0 {
+		return r
+	}
+
+	fitted := *r
+	fitted.config.ImageWidth = 0
+	fitted.config.ImageHeight = 0
+
+	// Solve for the cell width; the height follows from the aspect
+	wallRatio := float64(r.config.WallThickness) / float64(r.cellWidth())
+	aspect := float64(r.cellHeight()) / float64(r.cellWidth())
+	cellWidth := math.Inf(1)
+	if r.config.ImageWidth > 0 {
+		available := r.config.ImageWidth - 2*r.config.Padding
+		cellWidth = min(cellWidth, float64(available)/(float64(maze.Width)+wallRatio))
+	}
+	if r.config.ImageHeight > 0 {
+		available := r.config.ImageHeight - 2*r.config.Padding - fitted.headerHeight() - fitted.footerHeight()
+		cellWidth = min(cellWidth, float64(available)/(float64(maze.Height)*aspect+wallRatio))
+	}
+
+	fitted.config.CellSize = max(int(cellWidth), 1)
+	if r.config.CellWidth > 0 || r.config.CellHeight > 0 {
+		fitted.config.CellWidth = fitted.config.CellSize
+		fitted.config.CellHeight = max(int(cellWidth*aspect), 1)
+	}
+	fitted.scaleStrokes(r.config, r.cellWidth())
+	return &fitted
+}
+
+// scaleStrokes scales the wall, solution, and hand-drawn jitter widths of the
+// original config, drawn with cells cellWidth pixels wide, to the renderer's CellSize
+func (r *Renderer) scaleStrokes(original RenderConfig, cellWidth int) {
+	scale := func(thickness int) int {
+		return max(int(float64(r.config.CellSize)*float64(thickness)/float64(cellWidth)), 1)
+	}
+	r.config.WallThickness = scale(original.WallThickness)
+	if original.SolutionThickness > 0 {
+		r.config.SolutionThickness = scale(original.SolutionThickness)
+	}
+	if original.HandDrawnJitter > 0 {
+		r.config.HandDrawnJitter = scale(original.HandDrawnJitter)
+	}
+}
+
+// fitSquareCells is fitToSize for the polar and hex layouts, whose cells are
+// always square. The image is widthCells by heightCells cell sizes plus one wall
+// thickness, the padding, and the legend header.
+func (r *Renderer) fitSquareCells(widthCells, heightCells float64) *Renderer {
+	r = r.withSquareCells()
+	if r.config.ImageWidth <= 0 && r.config.ImageHeight <= 0 {
+		return r
+	}
+
+	fitted := *r
+	fitted.config.ImageWidth = 0
+	fitted.config.ImageHeight = 0
+
+	wallRatio := float64(r.config.WallThickness) / float64(r.config.CellSize)
+	cellSize := math.Inf(1)
+	if r.config.ImageWidth > 0 {
+		available := r.config.ImageWidth - 2*r.config.Padding
+		cellSize = min(cellSize, float64(available)/(widthCells+wallRatio))
+	}
+	if r.config.ImageHeight > 0 {
+		available := r.config.ImageHeight - 2*r.config.Padding - fitted.headerHeight()
+		cellSize = min(cellSize, float64(available)/(heightCells+wallRatio))
+	}
+
+	fitted.config.CellSize = max(int(cellSize), 1)
+	fitted.scaleStrokes(r.config, r.config.CellSize)
+	return &fitted
+}
+
+// headerHeight returns the height of the legend header, which is zero when the legend is hidden
+func (r *Renderer) headerHeight() int {
+	if !r.config.ShowLegend {
+		return 0
+	}
+	return r.config.HeaderHeight
+}
+
+// footerHeight returns the height of the footer area below the maze.
+// The footer is only present when a scale bar is drawn and matches the header height.
+func (r *Renderer) footerHeight() int {
+	if r.config.ScaleBar {
+		return r.config.HeaderHeight
+	}
+	return 0
+}
+
+// drawScaleBar draws a labeled bar in the footer showing the length of a fixed number of cells
+func (r *Renderer) drawScaleBar(img *image.RGBA, maze *Maze) {
+	bars, label, labelArea := r.scaleBarLayout(maze, img.Bounds().Max.X, img.Bounds().Max.Y)
+
+	wallColor := &image.Uniform{r.config.WallColor}
+	for _, bar := range bars {
+		draw.Draw(img, bar, wallColor, image.Point{}, draw.Src)
+	}
+
+	r.drawScaledText(img, label, r.config.LegendFontSize, labelArea)
+}
+
+// scaleBarLayout computes the rectangles making up the scale bar and its ticks,
+// along with the label text and the area it should be centered in
+func (r *Renderer) scaleBarLayout(maze *Maze, imgWidth, imgHeight int) ([]image.Rectangle, string, image.Rectangle) {
+	// Span five cells, or the full maze width if it is narrower
+	cells := min(scaleBarCells, maze.Width)
+
+	footerTop := imgHeight - r.footerHeight()
+	barLength := cells * r.cellWidth()
+	barX := (imgWidth - barLength) / 2
+	barY := footerTop + r.footerHeight()/4
+	thickness := r.config.WallThickness
+	tickHeight := 3 * thickness
+
+	// The bar itself with ticks at both ends
+	bars := []image.Rectangle{
+		image.Rect(barX, barY, barX+barLength+thickness, barY+thickness),
+		image.Rect(barX, barY-tickHeight/2, barX+thickness, barY+tickHeight/2+thickness),
+		image.Rect(barX+barLength, barY-tickHeight/2, barX+barLength+thickness, barY+tickHeight/2+thickness),
+	}
+
+	// Label the bar, e.g. "5 cells = 10 ft"
+	label := fmt.Sprintf("%d cells", cells)
+	if cells == 1 {
+		label = "1 cell"
+	}
+	if r.config.ScaleLabel != "" {
+		label = fmt.Sprintf("%s = %s", label, r.config.ScaleLabel)
+	}
+
+	labelArea := image.Rect(0, barY+tickHeight/2, imgWidth, imgHeight)
+	return bars, label, labelArea
+}
+
 // loadFont attempts to load a Unicode-capable font, falls back to basic font
 func (r *Renderer) loadFont() font.Face {
 	// If a specific font path is provided, try to load it
@@ -347,14 +1527,35 @@ func (r *Renderer) getSystemFontPaths() []string {
 	return fontPaths
 }
 
-// loadFontFromPath attempts to load a TrueType font from the given path
+// loadFontFromPath attempts to load a TrueType or OpenType font from the given path.
+// The face is sized to match the scaled legend text. Returns nil if the font
+// can't be read or parsed.
 func (r *Renderer) loadFontFromPath(fontPath string) font.Face {
-	// Check if file exists
-	if _, err := os.Stat(fontPath); os.IsNotExist(err) {
+	data, err := os.ReadFile(fontPath)
+	if err != nil {
+		return nil
+	}
+
+	// Font collections (.ttc) hold several fonts; use the first one
+	parsed, err := opentype.Parse(data)
+	if err != nil {
+		collection, collectionErr := opentype.ParseCollection(data)
+		if collectionErr != nil || collection.NumFonts() == 0 {
+			return nil
+		}
+		if parsed, err = collection.Font(0); err != nil {
+			return nil
+		}
+	}
+
+	face, err := opentype.NewFace(parsed, &opentype.FaceOptions{
+		Size:    float64(basicFontHeight * r.config.LegendFontSize),
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
 		return nil
 	}
 
-	// For now, return nil to use fallback - we'll implement TrueType loading if needed
-	// This allows the code to compile and run with the basic font
-	return nil
+	return face
 }
@@ -0,0 +1,160 @@
+package maze
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font/basicfont"
+)
+
+// testFontPath is a TrueType font bundled for the font loading tests
+const testFontPath = "testdata/Go-Regular.ttf"
+
+func TestLoadFontFromPath(t *testing.T) {
+	config := DefaultRenderConfig()
+	config.FontPath = testFontPath
+	r := NewRenderer(config)
+
+	face := r.loadFontFromPath(testFontPath)
+	if face == nil {
+		t.Fatalf("loadFontFromPath(%q) returned nil", testFontPath)
+	}
+	if face == basicfont.Face7x13 {
+		t.Errorf("loadFontFromPath(%q) returned the basic font", testFontPath)
+	}
+	if face := r.loadFont(); face == basicfont.Face7x13 {
+		t.Errorf("loadFont with FontPath %q fell back to the basic font", testFontPath)
+	}
+}
+
+func TestLoadFontFromPathMissing(t *testing.T) {
+	if face := NewDefaultRenderer().loadFontFromPath("testdata/missing.ttf"); face != nil {
+		t.Errorf("loadFontFromPath of a missing file returned %v, want nil", face)
+	}
+}
+
+func TestDefaultMarkerUsesDefaultShapes(t *testing.T) {
+	config := DefaultRenderConfig()
+	config.StartMarker = DefaultMarker
+	config.FinishMarker = DefaultMarker
+	r := NewRenderer(config)
+	if r.config.StartMarker != Circle || r.config.FinishMarker != Square {
+		t.Errorf("markers = %v, %v, want Circle, Square", r.config.StartMarker, r.config.FinishMarker)
+	}
+
+	config.FinishMarker = Triangle
+	if r := NewRenderer(config); r.config.FinishMarker != Triangle {
+		t.Errorf("FinishMarker = %v, want Triangle", r.config.FinishMarker)
+	}
+}
+
+func TestPolarAndHexImagesFitBounds(t *testing.T) {
+	config := DefaultRenderConfig()
+	config.ImageWidth = 300
+	config.ImageHeight = 400
+	r := NewRenderer(config)
+	g := NewGeneratorWithSeed(1)
+
+	images := map[string]*image.RGBA{
+		"polar": r.createPolarImage(g.GeneratePolar(12)),
+		"hex":   r.createHexImage(g.GenerateHex(20, 15)),
+	}
+	for name, img := range images {
+		size := img.Bounds().Size()
+		if size.X > config.ImageWidth || size.Y > config.ImageHeight {
+			t.Errorf("%s image is %dx%d, want at most %dx%d", name, size.X, size.Y, config.ImageWidth, config.ImageHeight)
+		}
+		if size.X < config.ImageWidth*3/4 && size.Y < config.ImageHeight*3/4 {
+			t.Errorf("%s image is %dx%d, far smaller than the %dx%d bounds", name, size.X, size.Y, config.ImageWidth, config.ImageHeight)
+		}
+	}
+}
+
+func TestCheckRenderableWithBoundsSuggestsBounds(t *testing.T) {
+	config := DefaultRenderConfig()
+	config.ImageWidth = 2000
+	err := NewRenderer(config).CheckRenderable(NewGeneratorWithSeed(1).Generate(10, 10), 1000)
+	if err == nil {
+		t.Fatal("CheckRenderable succeeded, want an error")
+	}
+	if strings.Contains(err.Error(), "CellSize") {
+		t.Errorf("error %q suggests a CellSize, which ImageWidth overrides", err)
+	}
+}
+
+// textPixels returns the bounding box of the pixels drawn in c
+func textPixels(img *image.RGBA, c color.Color) image.Rectangle {
+	var box image.Rectangle
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if img.At(x, y) == c {
+				box = box.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return box
+}
+
+func TestMarkerLabelClearsInteriorMarker(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	for _, cellSize := range []int{84, 30} {
+		config := DefaultRenderConfig()
+		config.CellSize = cellSize
+		config.ShowLegend = false
+		config.TextColor = red
+		config.StartLabel = "START"
+		r := NewRenderer(config)
+
+		m := NewGeneratorWithSeed(1).Generate(5, 5)
+		m.Start = Point{2, 2}
+		img := r.createImage(m)
+
+		text := textPixels(img, red)
+		if text.Empty() {
+			t.Fatalf("cell size %d: no label was drawn", cellSize)
+		}
+		_, centerY := r.cellCenter(m.Start)
+		_, radiusY := r.markerRadii()
+		if text.Min.Y <= centerY+radiusY {
+			t.Errorf("cell size %d: label starts at y=%d, overlapping the marker ending at y=%d",
+				cellSize, text.Min.Y, centerY+radiusY)
+		}
+	}
+}
+
+func TestGrowWithinKeepsLabelInsideImage(t *testing.T) {
+	bounds := image.Rect(0, 0, 200, 100)
+	got := growWithin(image.Rect(0, 40, 20, 60), 60, 13, bounds)
+	if got.Dx() != 60 || !got.In(bounds) {
+		t.Errorf("growWithin = %v, want a 60 pixel wide area inside %v", got, bounds)
+	}
+}
+
+func TestPolarAndHexImagesUseBorderAndGradient(t *testing.T) {
+	border := color.RGBA{0, 0, 255, 255}
+	top, bottom := color.RGBA{255, 0, 0, 255}, color.RGBA{0, 255, 0, 255}
+	config := DefaultRenderConfig()
+	config.CellSize = 20
+	config.Padding = 10
+	config.ShowLegend = false
+	config.BorderColor = border
+	config.BackgroundGradient = &[2]color.Color{top, bottom}
+	r := NewRenderer(config)
+	g := NewGeneratorWithSeed(1)
+
+	images := map[string]*image.RGBA{
+		"polar": r.createPolarImage(g.GeneratePolar(4)),
+		"hex":   r.createHexImage(g.GenerateHex(5, 4)),
+	}
+	for name, img := range images {
+		if got := img.At(0, 0); got != border {
+			t.Errorf("%s: padding is %v, want border color %v", name, got, border)
+		}
+		if got := img.At(config.Padding+1, config.Padding); got != top {
+			t.Errorf("%s: top of the maze area is %v, want gradient start %v", name, got, top)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package maze
+
+// StepGenerator carves a maze one passage at a time using recursive backtracking.
+// It makes the same random choices as Generator.Generate, so stepping to
+// completion produces the same maze as Generate with the same seed.
+type StepGenerator struct {
+	generator *Generator
+	maze      *Maze
+	stack     []stepFrame
+}
+
+// stepFrame records the progress of one cell in the backtracking search,
+// mirroring a single call of the recursive algorithm
+type stepFrame struct {
+	cell      *Cell
+	neighbors []*Cell
+	next      int
+}
+
+// NewStepGenerator creates a step-by-step generator that draws randomness from the given generator
+func NewStepGenerator(generator *Generator) *StepGenerator {
+	return &StepGenerator{generator: generator}
+}
+
+// Init starts a new maze of the given size, picking a random starting cell
+func (s *StepGenerator) Init(width, height int) {
+	s.maze = NewMaze(width, height)
+	s.stack = s.stack[:0]
+
+	// Start from a random cell, or the top-left one when shuffling is disabled
+	start := s.maze.GetCell(0, 0)
+	if !s.generator.DisableShuffle {
+		start = s.maze.GetCell(s.generator.rng.Intn(width), s.generator.rng.Intn(height))
+	}
+	s.enter(start)
+}
+
+// Step carves a single passage and returns the cells it connected.
+// It returns false once the maze is complete.
+func (s *StepGenerator) Step() (carved bool, from, to Point) {
+	for len(s.stack) > 0 {
+		frame := &s.stack[len(s.stack)-1]
+
+		// Find the next neighbor that is still unvisited
+		for frame.next < len(frame.neighbors) {
+			neighbor := frame.neighbors[frame.next]
+			frame.next++
+
+			if !neighbor.Visited {
+				current := frame.cell
+				s.maze.RemoveWall(current, neighbor)
+				s.enter(neighbor)
+				return true, Point{current.X, current.Y}, Point{neighbor.X, neighbor.Y}
+			}
+		}
+
+		// No neighbors left, backtrack
+		s.stack = s.stack[:len(s.stack)-1]
+	}
+
+	return false, Point{}, Point{}
+}
+
+// Maze returns the maze being generated
+func (s *StepGenerator) Maze() *Maze {
+	return s.maze
+}
+
+// enter visits a cell and pushes its shuffled unvisited neighbors onto the stack
+func (s *StepGenerator) enter(cell *Cell) {
+	cell.Visited = true
+
+	neighbors := s.generator.getUnvisitedNeighbors(s.maze, cell)
+	if !s.generator.DisableShuffle {
+		s.generator.shuffleNeighbors(neighbors)
+	}
+
+	s.stack = append(s.stack, stepFrame{cell: cell, neighbors: neighbors})
+}
@@ -0,0 +1,165 @@
+package maze
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"io"
+	"os"
+	"strings"
+)
+
+// RenderToSVG renders the maze to an SVG file.
+// Coordinates match the PNG output and the viewBox matches GetImageDimensions,
+// so the image scales to any print size without losing quality.
+func (r *Renderer) RenderToSVG(maze *Maze, filename string) error {
+	r = r.fitToSize(maze)
+	file, err := os.Create(filename)
+	if err != nil {
+		return renderError(filename, err)
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	r.writeSVG(w, maze)
+	return renderError(filename, w.Flush())
+}
+
+// writeSVG writes the SVG document for the maze
+func (r *Renderer) writeSVG(w io.Writer, maze *Maze) {
+	width, height := r.GetImageDimensions(maze)
+
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		width, height, width, height)
+
+	// Background and header, matching the PNG layering
+	background := svgColor(r.config.PathColor)
+	if gradient := r.config.BackgroundGradient; gradient != nil {
+		fmt.Fprintf(w, `<defs><linearGradient id="background" x1="0" y1="0" x2="0" y2="1"><stop offset="0" stop-color="%s"/><stop offset="1" stop-color="%s"/></linearGradient></defs>`+"\n",
+			svgColor(gradient[0]), svgColor(gradient[1]))
+		background = "url(#background)"
+	}
+	backgroundArea := image.Rect(0, 0, width, height)
+	if r.config.BorderColor != nil {
+		fmt.Fprintf(w, `<rect x="0" y="0" width="%d" height="%d" fill="%s"/>`+"\n", width, height, svgColor(r.config.BorderColor))
+		backgroundArea = r.mazeBounds(maze)
+	}
+	fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+		backgroundArea.Min.X, backgroundArea.Min.Y, backgroundArea.Dx(), backgroundArea.Dy(), background)
+	if headerColor := r.headerColor(); headerColor != nil {
+		fmt.Fprintf(w, `<rect x="0" y="0" width="%d" height="%d" fill="%s"/>`+"\n", width, r.headerHeight(), svgColor(headerColor))
+	}
+
+	// Legend text, using the Unicode symbols since SVG viewers provide real fonts
+	fontSize := basicFontHeight * r.config.LegendFontSize
+	if r.config.ShowLegend {
+		r.writeSVGText(w, r.legendText(true), fontSize, image.Rect(0, 0, width, r.headerHeight()))
+	}
+
+	// Guide grid beneath the walls
+	if r.config.ShowGrid {
+		fmt.Fprintf(w, `<g fill="%s">`+"\n", svgColor(r.gridColor()))
+		for _, line := range r.gridLines(maze) {
+			writeSVGRect(w, line)
+		}
+		fmt.Fprintln(w, "</g>")
+	}
+
+	// Walls
+	fmt.Fprintf(w, `<g fill="%s">`+"\n", svgColor(r.config.WallColor))
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+			for _, dir := range AllDirections() {
+				// Skip east/south walls already emitted as the neighbor's west/north wall
+				if dir == East && x+1 < maze.Width && r.wallVisible(maze, maze.GetCell(x+1, y), West) {
+					continue
+				}
+				if dir == South && y+1 < maze.Height && r.wallVisible(maze, maze.GetCell(x, y+1), North) {
+					continue
+				}
+				if r.wallVisible(maze, cell, dir) {
+					writeSVGRect(w, r.wallRect(x, y, dir))
+				}
+			}
+		}
+	}
+	fmt.Fprintln(w, "</g>")
+
+	// Start and finish markers, drawn as outlines
+	startX, startY := r.cellCenter(maze.Start)
+	r.writeSVGMarker(w, r.config.StartMarker, startX, startY)
+	for _, finish := range maze.AllFinishes() {
+		finishX, finishY := r.cellCenter(finish)
+		r.writeSVGMarker(w, r.config.FinishMarker, finishX, finishY)
+	}
+
+	// Scale bar footer
+	if r.config.ScaleBar {
+		bars, label, labelArea := r.scaleBarLayout(maze, width, height)
+		fmt.Fprintf(w, `<g fill="%s">`+"\n", svgColor(r.config.WallColor))
+		for _, bar := range bars {
+			writeSVGRect(w, bar)
+		}
+		fmt.Fprintln(w, "</g>")
+		r.writeSVGText(w, label, fontSize, labelArea)
+	}
+
+	fmt.Fprintln(w, "</svg>")
+}
+
+// writeSVGMarker writes a marker outline centered at the given position.
+// Strokes are inset by half their width so they cover the same pixels as the PNG markers.
+func (r *Renderer) writeSVGMarker(w io.Writer, shape MarkerShape, centerX, centerY int) {
+	stroke := svgColor(r.config.WallColor)
+	half := float64(markerThickness) / 2
+	radiusX, radiusY := r.markerRadii()
+	rx, ry := float64(radiusX), float64(radiusY)
+
+	switch shape {
+	case Square:
+		halfWidth, halfHeight := r.squareMarkerHalfSize()
+		fmt.Fprintf(w, `<rect x="%g" y="%g" width="%g" height="%g" fill="none" stroke="%s" stroke-width="%d"/>`+"\n",
+			float64(centerX-halfWidth)+half, float64(centerY-halfHeight)+half,
+			float64(2*halfWidth)-2*half, float64(2*halfHeight)-2*half, stroke, markerThickness)
+	case Triangle, Diamond:
+		var points []string
+		for _, v := range markerPolygon(shape, float64(centerX), float64(centerY), rx-half, ry-half) {
+			points = append(points, fmt.Sprintf("%g,%g", v[0], v[1]))
+		}
+		fmt.Fprintf(w, `<polygon points="%s" fill="none" stroke="%s" stroke-width="%d"/>`+"\n",
+			strings.Join(points, " "), stroke, markerThickness)
+	case Cross:
+		armX, armY := markerCrossArm(rx), markerCrossArm(ry)
+		x, y := float64(centerX), float64(centerY)
+		fmt.Fprintf(w, `<path d="M%g %gL%g %gM%g %gL%g %g" stroke="%s" stroke-width="%d"/>`+"\n",
+			x-armX, y-armY, x+armX, y+armY, x-armX, y+armY, x+armX, y-armY, stroke, markerThickness)
+	default:
+		if rx != ry {
+			fmt.Fprintf(w, `<ellipse cx="%d" cy="%d" rx="%g" ry="%g" fill="none" stroke="%s" stroke-width="%d"/>`+"\n",
+				centerX, centerY, rx-half, ry-half, stroke, markerThickness)
+			return
+		}
+		fmt.Fprintf(w, `<circle cx="%d" cy="%d" r="%g" fill="none" stroke="%s" stroke-width="%d"/>`+"\n",
+			centerX, centerY, rx-half, stroke, markerThickness)
+	}
+}
+
+// writeSVGText writes a text element centered in the given area
+func (r *Renderer) writeSVGText(w io.Writer, text string, fontSize int, area image.Rectangle) {
+	fmt.Fprintf(w, `<text x="%d" y="%d" font-family="sans-serif" font-size="%d" fill="%s" text-anchor="middle" dominant-baseline="middle" xml:space="preserve">%s</text>`+"\n",
+		area.Min.X+area.Dx()/2, area.Min.Y+area.Dy()/2, fontSize, svgColor(r.config.TextColor), html.EscapeString(text))
+}
+
+// writeSVGRect writes a rect element covering the given rectangle
+func writeSVGRect(w io.Writer, rect image.Rectangle) {
+	fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d"/>`+"\n", rect.Min.X, rect.Min.Y, rect.Dx(), rect.Dy())
+}
+
+// svgColor converts a color to an SVG hex color string
+func svgColor(c color.Color) string {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	return fmt.Sprintf("#%02x%02x%02x", rgba.R, rgba.G, rgba.B)
+}
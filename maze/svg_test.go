@@ -0,0 +1,23 @@
+package maze
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSVGStartMarkerIsCircle(t *testing.T) {
+	var buf bytes.Buffer
+	NewDefaultRenderer().writeSVG(&buf, NewGeneratorWithSeed(1).Generate(4, 4))
+	if !strings.Contains(buf.String(), "<circle ") {
+		t.Error("SVG with square cells has no <circle> start marker")
+	}
+
+	config := DefaultRenderConfig()
+	config.CellWidth, config.CellHeight = 60, 90
+	buf.Reset()
+	NewRenderer(config).writeSVG(&buf, NewGeneratorWithSeed(1).Generate(4, 4))
+	if !strings.Contains(buf.String(), "<ellipse ") {
+		t.Error("SVG with rectangular cells has no <ellipse> start marker")
+	}
+}
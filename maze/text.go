@@ -0,0 +1,270 @@
+package maze
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderToASCII renders the maze as plain text using '+', '-', and '|' characters.
+// Each cell is three characters wide with the start marked 'S' and the finish 'F'.
+// Every wall is drawn as stored, including the outer walls at start and finish,
+// so the output describes the maze structure exactly.
+func (r *Renderer) RenderToASCII(maze *Maze) string {
+	return renderASCII(maze, func(x, y int) byte {
+		return asciiMarker(maze, x, y)
+	})
+}
+
+// renderASCII draws the maze walls as text, calling marker for the character in each cell
+func renderASCII(maze *Maze, marker func(x, y int) byte) string {
+	var sb strings.Builder
+
+	for y := 0; y < maze.Height; y++ {
+		// Top border of the row
+		for x := 0; x < maze.Width; x++ {
+			sb.WriteByte('+')
+			if maze.GetCell(x, y).HasWall(North) {
+				sb.WriteString("---")
+			} else {
+				sb.WriteString("   ")
+			}
+		}
+		sb.WriteString("+\n")
+
+		// Cell interiors with their west walls
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+			if cell.HasWall(West) {
+				sb.WriteByte('|')
+			} else {
+				sb.WriteByte(' ')
+			}
+
+			sb.WriteByte(' ')
+			sb.WriteByte(marker(x, y))
+			sb.WriteByte(' ')
+		}
+		if maze.GetCell(maze.Width-1, y).HasWall(East) {
+			sb.WriteByte('|')
+		} else {
+			sb.WriteByte(' ')
+		}
+		sb.WriteByte('\n')
+	}
+
+	// Bottom border from the last row's south walls
+	for x := 0; x < maze.Width; x++ {
+		sb.WriteByte('+')
+		if maze.GetCell(x, maze.Height-1).HasWall(South) {
+			sb.WriteString("---")
+		} else {
+			sb.WriteString("   ")
+		}
+	}
+	sb.WriteString("+\n")
+
+	return sb.String()
+}
+
+// asciiMarker returns the character drawn in the middle of a cell
+func asciiMarker(maze *Maze, x, y int) byte {
+	switch {
+	case x == maze.Start.X && y == maze.Start.Y:
+		return 'S'
+	case maze.isFinish(Point{x, y}):
+		return 'F'
+	default:
+		return ' '
+	}
+}
+
+// ParseASCII reconstructs a maze from the text produced by RenderToASCII.
+// Rows must all be the same length, with '+' at every corner, "---" or three
+// spaces between corners, and '|' or a space between cells. A cell may contain
+// 'S' or 'F' to mark the start or finish, and several 'F' cells make a
+// multi-goal maze; without them the start defaults to the top-left cell and
+// the finish to the bottom-right.
+func ParseASCII(text string) (*Maze, error) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+
+	// Infer the dimensions from the grid size
+	if len(lines) < 3 || len(lines)%2 == 0 {
+		return nil, fmt.Errorf("ascii: expected an odd number of at least 3 lines, got %d", len(lines))
+	}
+	lineLength := len(lines[0])
+	if lineLength < 5 || (lineLength-1)%4 != 0 {
+		return nil, fmt.Errorf("ascii: line 1 has length %d, want 4*width+1", lineLength)
+	}
+	for i, line := range lines {
+		if len(line) != lineLength {
+			return nil, fmt.Errorf("ascii: line %d has length %d, want %d", i+1, len(line), lineLength)
+		}
+	}
+
+	width := (lineLength - 1) / 4
+	height := (len(lines) - 1) / 2
+	maze := NewMaze(width, height)
+	maze.Start = Point{0, 0}
+	maze.Finish = Point{width - 1, height - 1}
+	var hasStart bool
+	var finishes []Point
+
+	// Each character is validated once, then applied to the cells on either side
+	for row, line := range lines {
+		for col := 0; col < lineLength; col++ {
+			ch := line[col]
+			x, offset := col/4, col%4
+			y := row / 2
+			bad := func() error {
+				return fmt.Errorf("ascii: unexpected %q at line %d, column %d", ch, row+1, col+1)
+			}
+
+			// Border lines: corners and horizontal walls
+			if row%2 == 0 {
+				if offset == 0 {
+					if ch != '+' {
+						return nil, bad()
+					}
+					continue
+				}
+				segment := line[col-offset+1 : col-offset+4]
+				if segment != "---" && segment != "   " {
+					return nil, bad()
+				}
+				if offset == 1 {
+					wall := segment == "---"
+					if cell := maze.GetCell(x, y-1); cell != nil {
+						cell.SetWall(South, wall)
+					}
+					if cell := maze.GetCell(x, y); cell != nil {
+						cell.SetWall(North, wall)
+					}
+				}
+				continue
+			}
+
+			// Cell lines: vertical walls and markers
+			switch {
+			case offset == 0 && (ch == '|' || ch == ' '):
+				wall := ch == '|'
+				if cell := maze.GetCell(x-1, y); cell != nil {
+					cell.SetWall(East, wall)
+				}
+				if cell := maze.GetCell(x, y); cell != nil {
+					cell.SetWall(West, wall)
+				}
+			case offset == 2 && ch == 'S' && !hasStart:
+				maze.Start, hasStart = Point{x, y}, true
+			case offset == 2 && ch == 'F':
+				finishes = append(finishes, Point{x, y})
+			case offset != 0 && ch == ' ':
+			default:
+				return nil, bad()
+			}
+		}
+	}
+
+	// Several finishes make a multi-goal maze
+	switch {
+	case len(finishes) == 1:
+		maze.Finish = finishes[0]
+	case len(finishes) > 1:
+		maze.SetFinishes(finishes)
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			maze.GetCell(x, y).Visited = true
+		}
+	}
+
+	return maze, nil
+}
+
+// boxGlyphs maps the walls meeting at a corner to a box-drawing character.
+// The index is a bitmask of arms: up=1, right=2, down=4, left=8.
+var boxGlyphs = [16]string{
+	" ", "╵", "╶", "└", "╷", "│", "┌", "├",
+	"╴", "┘", "─", "┴", "┐", "┤", "┬", "┼",
+}
+
+// RenderToUnicode renders the maze compactly using box-drawing characters.
+// Each cell is two characters wide and two lines tall, with corner glyphs chosen
+// from the walls that meet there. The start is marked ○ and the finish ■ to
+// match the PNG legend.
+func (r *Renderer) RenderToUnicode(maze *Maze) string {
+	var sb strings.Builder
+
+	for y := 0; y <= maze.Height; y++ {
+		// Corner row: junction glyphs joined by horizontal walls
+		for x := 0; x <= maze.Width; x++ {
+			sb.WriteString(boxGlyphs[cornerArms(maze, x, y)])
+			if x < maze.Width {
+				if hasWall(maze, x, y, North) || hasWall(maze, x, y-1, South) {
+					sb.WriteString("─")
+				} else {
+					sb.WriteString(" ")
+				}
+			}
+		}
+		sb.WriteByte('\n')
+
+		if y == maze.Height {
+			break
+		}
+
+		// Cell row: vertical walls with the markers between them
+		for x := 0; x <= maze.Width; x++ {
+			if hasWall(maze, x, y, West) || hasWall(maze, x-1, y, East) {
+				sb.WriteString("│")
+			} else {
+				sb.WriteString(" ")
+			}
+			if x < maze.Width {
+				sb.WriteString(unicodeMarker(maze, x, y))
+			}
+		}
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+// cornerArms returns the bitmask of walls meeting at the corner above and to the
+// left of cell (x, y), examining the walls of all four cells around it
+func cornerArms(maze *Maze, x, y int) int {
+	arms := 0
+	if hasWall(maze, x, y-1, West) || hasWall(maze, x-1, y-1, East) {
+		arms |= 1 // up
+	}
+	if hasWall(maze, x, y, North) || hasWall(maze, x, y-1, South) {
+		arms |= 2 // right
+	}
+	if hasWall(maze, x, y, West) || hasWall(maze, x-1, y, East) {
+		arms |= 4 // down
+	}
+	if hasWall(maze, x-1, y, North) || hasWall(maze, x-1, y-1, South) {
+		arms |= 8 // left
+	}
+	return arms
+}
+
+// hasWall reports whether the cell at (x, y) has a wall in the given direction.
+// Coordinates outside the maze have no walls.
+func hasWall(maze *Maze, x, y int, dir Direction) bool {
+	cell := maze.GetCell(x, y)
+	return cell != nil && cell.HasWall(dir)
+}
+
+// unicodeMarker returns the string drawn inside a cell
+func unicodeMarker(maze *Maze, x, y int) string {
+	switch {
+	case x == maze.Start.X && y == maze.Start.Y:
+		return "○"
+	case maze.isFinish(Point{x, y}):
+		return "■"
+	default:
+		return " "
+	}
+}
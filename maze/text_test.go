@@ -0,0 +1,22 @@
+package maze
+
+import "testing"
+
+func TestRenderToASCIIGolden(t *testing.T) {
+	m := NewGeneratorWithSeed(7).Generate(3, 3)
+	m.Start = Point{0, 0}
+	m.Finish = Point{2, 2}
+
+	want := "" +
+		"+---+---+---+\n" +
+		"| S     |   |\n" +
+		"+---+   +   +\n" +
+		"|       |   |\n" +
+		"+   +---+   +\n" +
+		"|         F |\n" +
+		"+---+---+---+\n"
+
+	if got := NewDefaultRenderer().RenderToASCII(m); got != want {
+		t.Errorf("RenderToASCII mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,227 @@
+package maze
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Transformer handles deriving new mazes from existing ones
+type Transformer struct{}
+
+// NewTransformer creates a new maze transformer
+func NewTransformer() *Transformer {
+	return &Transformer{}
+}
+
+// ComplementWalls returns the inverse of the given maze: every interior wall
+// that was present becomes a passage and every passage becomes a wall. Outer
+// boundary walls are always kept.
+//
+// The raw complement of a perfect maze is full of loops and may leave cells
+// isolated, so the result is pruned back to a perfect maze. A spanning tree is
+// taken over the complement's passages, and any remaining disconnected regions
+// are joined with Validator.RepairConnectivity. Every passage in the result is
+// therefore a wall in the source maze except for the few needed to reconnect.
+// Start, Finish, and the disabled cells of a masked maze are copied from the
+// source maze, and disabled cells keep all their walls.
+func (t *Transformer) ComplementWalls(maze *Maze) *Maze {
+	if maze == nil {
+		return nil
+	}
+
+	result := NewMaze(maze.Width, maze.Height)
+	result.Start = maze.Start
+	result.Finish = maze.Finish
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			result.Cells[y][x].Disabled = maze.Cells[y][x].Disabled
+		}
+	}
+
+	// Build a spanning forest over the complement's passages using BFS
+	visited := make(map[Point]bool)
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			if visited[Point{x, y}] || maze.Cells[y][x].Disabled {
+				continue
+			}
+
+			queue := []*Cell{maze.GetCell(x, y)}
+			visited[Point{x, y}] = true
+
+			for len(queue) > 0 {
+				current := queue[0]
+				queue = queue[1:]
+
+				for _, dir := range AllDirections() {
+					neighbor := maze.GetNeighbor(current, dir)
+					if neighbor == nil || neighbor.Disabled {
+						continue
+					}
+
+					// A wall in the source is a passage in the complement
+					neighborPoint := Point{neighbor.X, neighbor.Y}
+					if !visited[neighborPoint] && current.HasWall(dir) {
+						visited[neighborPoint] = true
+						result.RemoveWall(result.GetCell(current.X, current.Y), result.GetCell(neighbor.X, neighbor.Y))
+						queue = append(queue, neighbor)
+					}
+				}
+			}
+		}
+	}
+
+	// Join any regions the complement left isolated
+	NewValidator().RepairConnectivity(result)
+
+	for y := 0; y < result.Height; y++ {
+		for x := 0; x < result.Width; x++ {
+			result.Cells[y][x].Visited = true
+		}
+	}
+
+	return result
+}
+
+// Merge joins two mazes of equal height side by side into one maze
+// left.Width+right.Width cells wide. A single passage is opened through the
+// shared boundary, in the row nearest the middle where both cells are enabled,
+// so two perfect mazes merge into a perfect maze. Start comes from left and
+// the finishes from right. Both mazes must be diagonal or neither, and a nil
+// maze is an error.
+func Merge(left, right *Maze) (*Maze, error) {
+	if left == nil || right == nil {
+		return nil, errors.New("merge: nil maze")
+	}
+	for _, m := range []*Maze{left, right} {
+		if err := checkDimensions(m.Width, m.Height); err != nil {
+			return nil, fmt.Errorf("merge: %w", err)
+		}
+	}
+	if left.Height != right.Height {
+		return nil, fmt.Errorf("merge: heights differ, %d and %d", left.Height, right.Height)
+	}
+	if left.Diagonal != right.Diagonal {
+		return nil, errors.New("merge: cannot join a diagonal maze with a non-diagonal one")
+	}
+
+	merged := NewMaze(left.Width+right.Width, left.Height)
+	merged.Diagonal = left.Diagonal
+	shift := func(p Point) Point { return Point{p.X + left.Width, p.Y} }
+	merged.Start = left.Start
+	merged.Finish = shift(right.Finish)
+	for _, finish := range right.Finishes {
+		merged.Finishes = append(merged.Finishes, shift(finish))
+	}
+
+	for y := 0; y < merged.Height; y++ {
+		for x := 0; x < merged.Width; x++ {
+			src := left.GetCell(x, y)
+			if x >= left.Width {
+				src = right.GetCell(x-left.Width, y)
+			}
+			dst := merged.Cells[y][x]
+			dst.Visited = src.Visited
+			dst.Disabled = src.Disabled
+			dst.Walls = src.Walls
+		}
+	}
+
+	// Search outward from the middle row for a boundary both sides can reach
+	for i := 0; i < merged.Height; i++ {
+		y := merged.Height/2 + (i+1)/2*(1-2*(i%2))
+		a, b := merged.GetCell(left.Width-1, y), merged.GetCell(left.Width, y)
+		if !a.Disabled && !b.Disabled {
+			merged.RemoveWall(a, b)
+			return merged, nil
+		}
+	}
+
+	return nil, errors.New("merge: no row has enabled cells on both sides of the boundary")
+}
+
+// Rotate90 returns a copy of the maze rotated a quarter turn clockwise.
+// The copy is Height cells wide and Width cells tall, and each wall turns with
+// its cell, so a North wall becomes an East wall and a NorthEast wall becomes
+// a SouthEast wall.
+func (m *Maze) Rotate90() *Maze {
+	return m.remap(m.Height, m.Width,
+		func(p Point) Point { return Point{m.Height - 1 - p.Y, p.X} },
+		func(d Direction) Direction {
+			if d.IsDiagonal() {
+				return NorthEast + (d-NorthEast+1)%4
+			}
+			return (d + 1) % 4
+		})
+}
+
+// FlipHorizontal returns a mirror image of the maze with left and right swapped
+func (m *Maze) FlipHorizontal() *Maze {
+	return m.remap(m.Width, m.Height,
+		func(p Point) Point { return Point{m.Width - 1 - p.X, p.Y} },
+		func(d Direction) Direction {
+			switch d {
+			case East, West:
+				return d.Opposite()
+			case NorthEast:
+				return NorthWest
+			case NorthWest:
+				return NorthEast
+			case SouthEast:
+				return SouthWest
+			case SouthWest:
+				return SouthEast
+			}
+			return d
+		})
+}
+
+// FlipVertical returns a mirror image of the maze with top and bottom swapped
+func (m *Maze) FlipVertical() *Maze {
+	return m.remap(m.Width, m.Height,
+		func(p Point) Point { return Point{p.X, m.Height - 1 - p.Y} },
+		func(d Direction) Direction {
+			switch d {
+			case North, South:
+				return d.Opposite()
+			case NorthEast:
+				return SouthEast
+			case SouthEast:
+				return NorthEast
+			case NorthWest:
+				return SouthWest
+			case SouthWest:
+				return NorthWest
+			}
+			return d
+		})
+}
+
+// remap returns a width by height copy of the maze with every cell moved by
+// point and every wall direction changed by dir, along with the start, finishes,
+// and Diagonal flag. Diagonal walls are remapped too.
+func (m *Maze) remap(width, height int, point func(Point) Point, dir func(Direction) Direction) *Maze {
+	result := NewMaze(width, height)
+	result.Diagonal = m.Diagonal
+	result.Start = point(m.Start)
+	result.Finish = point(m.Finish)
+	for _, finish := range m.Finishes {
+		result.Finishes = append(result.Finishes, point(finish))
+	}
+
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			src := m.Cells[y][x]
+			p := point(Point{x, y})
+			dst := result.Cells[p.Y][p.X]
+			dst.Visited = src.Visited
+			dst.Disabled = src.Disabled
+			dst.Walls = 0
+			for _, d := range EightDirections() {
+				dst.SetWall(dir(d), src.HasWall(d))
+			}
+		}
+	}
+
+	return result
+}
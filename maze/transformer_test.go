@@ -0,0 +1,72 @@
+package maze
+
+import "testing"
+
+func TestTransformsKeepMazeSolvable(t *testing.T) {
+	transforms := []struct {
+		name  string
+		apply func(*Maze) *Maze
+	}{
+		{"Rotate90", (*Maze).Rotate90},
+		{"FlipHorizontal", (*Maze).FlipHorizontal},
+		{"FlipVertical", (*Maze).FlipVertical},
+	}
+
+	v := NewValidator()
+	for _, seed := range testSeeds {
+		m := testMaze(t, seed, 9, 6)
+		want := len(v.FindPath(m))
+		for _, tr := range transforms {
+			got := tr.apply(m)
+			if !v.HasPath(got) {
+				t.Errorf("%s seed %d: transformed maze has no path", tr.name, seed)
+				continue
+			}
+			if n := len(v.FindPath(got)); n != want {
+				t.Errorf("%s seed %d: path length %d, want %d", tr.name, seed, n, want)
+			}
+			if !v.IsPerfect(got) {
+				t.Errorf("%s seed %d: transformed maze is no longer perfect", tr.name, seed)
+			}
+		}
+	}
+}
+
+func TestTransformsKeepDiagonalPassages(t *testing.T) {
+	v := NewValidator()
+	for _, seed := range testSeeds {
+		m := NewGeneratorWithSeed(seed).GenerateDiagonal(7, 5)
+		want := len(v.FindPath(m))
+
+		rotated := m.Rotate90()
+		if !rotated.Diagonal {
+			t.Fatalf("seed %d: Rotate90 dropped Diagonal", seed)
+		}
+		if n := len(v.FindPath(rotated)); n != want {
+			t.Errorf("seed %d: rotated path length %d, want %d", seed, n, want)
+		}
+		if !m.Equal(rotated.Rotate90().Rotate90().Rotate90()) {
+			t.Errorf("seed %d: four quarter turns do not restore the maze", seed)
+		}
+		if !m.Equal(m.FlipHorizontal().FlipHorizontal()) {
+			t.Errorf("seed %d: flipping horizontally twice does not restore the maze", seed)
+		}
+		if !m.Equal(m.FlipVertical().FlipVertical()) {
+			t.Errorf("seed %d: flipping vertically twice does not restore the maze", seed)
+		}
+		for name, flipped := range map[string]*Maze{"FlipHorizontal": m.FlipHorizontal(), "FlipVertical": m.FlipVertical()} {
+			if n := len(v.FindPath(flipped)); n != want {
+				t.Errorf("%s seed %d: path length %d, want %d", name, seed, n, want)
+			}
+		}
+	}
+}
+
+func TestMergeRejectsNil(t *testing.T) {
+	m := NewGeneratorWithSeed(1).Generate(4, 4)
+	for _, pair := range [][2]*Maze{{nil, m}, {m, nil}, {nil, nil}} {
+		if merged, err := Merge(pair[0], pair[1]); err == nil || merged != nil {
+			t.Errorf("Merge(%v, %v) = %v, %v, want nil and an error", pair[0] != nil, pair[1] != nil, merged, err)
+		}
+	}
+}
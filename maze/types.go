@@ -1,8 +1,13 @@
 package maze
 
-import "image/color"
+import (
+	"fmt"
+	"image/color"
+	"image/png"
+)
 
-// Direction represents the four cardinal directions
+// Direction represents the four cardinal directions, followed by the four
+// diagonal directions used by diagonal mazes
 type Direction int
 
 const (
@@ -10,13 +15,206 @@ const (
 	East
 	South
 	West
+	NorthEast
+	SouthEast
+	SouthWest
+	NorthWest
 )
 
-// Cell represents a single cell in the maze
+// AllDirections returns the four cardinal directions in order
+func AllDirections() []Direction {
+	return []Direction{North, East, South, West}
+}
+
+// DiagonalDirections returns the four diagonal directions in clockwise order
+func DiagonalDirections() []Direction {
+	return []Direction{NorthEast, SouthEast, SouthWest, NorthWest}
+}
+
+// EightDirections returns the cardinal directions followed by the diagonal ones
+func EightDirections() []Direction {
+	return append(AllDirections(), DiagonalDirections()...)
+}
+
+// directionOffsets holds the change in x and y for a step in each direction
+var directionOffsets = [...][2]int{
+	North:     {0, -1},
+	East:      {1, 0},
+	South:     {0, 1},
+	West:      {-1, 0},
+	NorthEast: {1, -1},
+	SouthEast: {1, 1},
+	SouthWest: {-1, 1},
+	NorthWest: {-1, -1},
+}
+
+// Opposite returns the direction pointing the other way
+func (d Direction) Opposite() Direction {
+	if d.IsDiagonal() {
+		return NorthEast + (d-NorthEast+2)%4
+	}
+	return (d + 2) % 4
+}
+
+// IsDiagonal reports whether the direction is one of the four diagonals
+func (d Direction) IsDiagonal() bool {
+	return d >= NorthEast && d <= NorthWest
+}
+
+// Offset returns the change in x and y for one step in the direction,
+// with y increasing southward
+func (d Direction) Offset() (dx, dy int) {
+	if d < 0 || int(d) >= len(directionOffsets) {
+		return 0, 0
+	}
+	return directionOffsets[d][0], directionOffsets[d][1]
+}
+
+// directionOf returns the direction of a single step by dx and dy, if there is one
+func directionOf(dx, dy int) (Direction, bool) {
+	for dir, offset := range directionOffsets {
+		if offset == [2]int{dx, dy} {
+			return Direction(dir), true
+		}
+	}
+	return 0, false
+}
+
+// String returns the lowercase name of the direction
+func (d Direction) String() string {
+	switch d {
+	case North:
+		return "north"
+	case East:
+		return "east"
+	case South:
+		return "south"
+	case West:
+		return "west"
+	case NorthEast:
+		return "northeast"
+	case SouthEast:
+		return "southeast"
+	case SouthWest:
+		return "southwest"
+	case NorthWest:
+		return "northwest"
+	}
+	return fmt.Sprintf("Direction(%d)", int(d))
+}
+
+// MarshalText encodes the direction by name so it serializes stably
+func (d Direction) MarshalText() ([]byte, error) {
+	if d >= North && d <= NorthWest {
+		return []byte(d.String()), nil
+	}
+	return nil, fmt.Errorf("invalid direction %d", int(d))
+}
+
+// UnmarshalText decodes a direction from its name
+func (d *Direction) UnmarshalText(text []byte) error {
+	for _, dir := range EightDirections() {
+		if string(text) == dir.String() {
+			*d = dir
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid direction %q", text)
+}
+
+// GenerationAlgorithm selects the algorithm used to carve a maze
+type GenerationAlgorithm int
+
+const (
+	RecursiveBacktracking GenerationAlgorithm = iota
+	Prim
+	Kruskal
+	Wilson
+	RecursiveDivision
+	BinaryTree
+	Sidewinder
+	AldousBroder
+	HuntAndKill
+)
+
+// algorithmNames holds the name of each generation algorithm, indexed by its value
+var algorithmNames = []string{
+	RecursiveBacktracking: "recursive-backtracking",
+	Prim:                  "prim",
+	Kruskal:               "kruskal",
+	Wilson:                "wilson",
+	RecursiveDivision:     "recursive-division",
+	BinaryTree:            "binary-tree",
+	Sidewinder:            "sidewinder",
+	AldousBroder:          "aldous-broder",
+	HuntAndKill:           "hunt-and-kill",
+}
+
+// String returns the lowercase, hyphenated name of the algorithm
+func (a GenerationAlgorithm) String() string {
+	if a >= 0 && int(a) < len(algorithmNames) {
+		return algorithmNames[a]
+	}
+	return fmt.Sprintf("GenerationAlgorithm(%d)", int(a))
+}
+
+// MarshalText encodes the algorithm by name so it serializes stably
+func (a GenerationAlgorithm) MarshalText() ([]byte, error) {
+	if a >= 0 && int(a) < len(algorithmNames) {
+		return []byte(algorithmNames[a]), nil
+	}
+	return nil, fmt.Errorf("invalid generation algorithm %d", int(a))
+}
+
+// UnmarshalText decodes an algorithm from its name
+func (a *GenerationAlgorithm) UnmarshalText(text []byte) error {
+	for i, name := range algorithmNames {
+		if string(text) == name {
+			*a = GenerationAlgorithm(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid generation algorithm %q", text)
+}
+
+// SelectionPolicy chooses which active cell the growing-tree algorithm extends next
+type SelectionPolicy int
+
+const (
+	GrowingTreeNewest SelectionPolicy = iota // The most recently added cell, like recursive backtracking
+	GrowingTreeRandom                        // A random active cell, like Prim's algorithm
+	GrowingTreeOldest                        // The earliest added cell, giving long straight runs from the start
+)
+
+// MarkerShape selects the shape drawn for the start or finish marker. The zero
+// value, DefaultMarker, stands for the default shape of that marker.
+type MarkerShape int
+
+const (
+	DefaultMarker MarkerShape = iota // Circle for the start, Square for the finish
+	Circle
+	Square
+	Triangle
+	Cross
+	Diamond
+)
+
+// allWalls is the wall bitmask of a cell with every wall intact
+const allWalls uint8 = 1<<North | 1<<East | 1<<South | 1<<West
+
+// diagonalWalls is the wall bitmask of the four diagonal sides
+const diagonalWalls uint8 = 1<<NorthEast | 1<<SouthEast | 1<<SouthWest | 1<<NorthWest
+
+// Cell represents a single cell in the maze.
+// Walls is a bitmask with bit 1<<dir set when the wall on that side is present;
+// use HasWall and SetWall rather than manipulating it directly.
+// Disabled cells lie outside the shape of a masked maze: they keep every wall,
+// are never carved into, and are not drawn.
 type Cell struct {
-	X, Y    int
-	Visited bool
-	Walls   map[Direction]bool
+	X, Y     int
+	Visited  bool
+	Disabled bool
+	Walls    uint8
 }
 
 // NewCell creates a new cell with all walls intact
@@ -25,12 +223,21 @@ func NewCell(x, y int) *Cell {
 		X:       x,
 		Y:       y,
 		Visited: false,
-		Walls: map[Direction]bool{
-			North: true,
-			East:  true,
-			South: true,
-			West:  true,
-		},
+		Walls:   allWalls,
+	}
+}
+
+// HasWall reports whether the wall on the given side of the cell is present
+func (c *Cell) HasWall(dir Direction) bool {
+	return c.Walls&(1<<dir) != 0
+}
+
+// SetWall adds or removes the wall on the given side of the cell
+func (c *Cell) SetWall(dir Direction, wall bool) {
+	if wall {
+		c.Walls |= 1 << dir
+	} else {
+		c.Walls &^= 1 << dir
 	}
 }
 
@@ -39,11 +246,19 @@ type Point struct {
 	X, Y int
 }
 
-// Maze represents the entire maze structure
+// Maze represents the entire maze structure.
+// Finishes optionally lists several goals for multi-goal mazes; when set,
+// Finish mirrors its first element so single-goal code keeps working.
+// Diagonal mazes also connect each cell to its four diagonal neighbors
+// through the diagonal walls. Pathfinding, validation, JSON encoding, ToRLE,
+// PNG rendering, and the rotations and flips follow diagonal passages; the
+// analyzer statistics, ComplementWalls, and Merge only consider the cardinal walls.
 type Maze struct {
 	Width, Height int
 	Cells         [][]*Cell
 	Start, Finish Point
+	Finishes      []Point
+	Diagonal      bool
 }
 
 // NewMaze creates a new maze with the specified dimensions
@@ -63,6 +278,27 @@ func NewMaze(width, height int) *Maze {
 	}
 }
 
+// NewDiagonalMaze creates a new diagonal maze with all cardinal and diagonal walls intact
+func NewDiagonalMaze(width, height int) *Maze {
+	maze := NewMaze(width, height)
+	maze.Diagonal = true
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			maze.Cells[y][x].Walls |= diagonalWalls
+		}
+	}
+	return maze
+}
+
+// directions returns the directions a cell can connect in: all eight in a
+// diagonal maze, otherwise the four cardinal ones
+func (m *Maze) directions() []Direction {
+	if m.Diagonal {
+		return EightDirections()
+	}
+	return AllDirections()
+}
+
 // GetCell returns the cell at the given coordinates
 func (m *Maze) GetCell(x, y int) *Cell {
 	if x < 0 || x >= m.Width || y < 0 || y >= m.Height {
@@ -73,37 +309,92 @@ func (m *Maze) GetCell(x, y int) *Cell {
 
 // GetNeighbor returns the neighboring cell in the given direction
 func (m *Maze) GetNeighbor(cell *Cell, dir Direction) *Cell {
-	switch dir {
-	case North:
-		return m.GetCell(cell.X, cell.Y-1)
-	case East:
-		return m.GetCell(cell.X+1, cell.Y)
-	case South:
-		return m.GetCell(cell.X, cell.Y+1)
-	case West:
-		return m.GetCell(cell.X-1, cell.Y)
+	dx, dy := dir.Offset()
+	if dx == 0 && dy == 0 {
+		return nil
 	}
-	return nil
+	return m.GetCell(cell.X+dx, cell.Y+dy)
 }
 
-// RemoveWall removes the wall between two adjacent cells
+// RemoveWall removes the wall between two adjacent cells, including diagonal neighbors
 func (m *Maze) RemoveWall(cell1, cell2 *Cell) {
-	dx := cell2.X - cell1.X
-	dy := cell2.Y - cell1.Y
+	dir, ok := directionOf(cell2.X-cell1.X, cell2.Y-cell1.Y)
+	if !ok {
+		return
+	}
+
+	cell1.SetWall(dir, false)
+	cell2.SetWall(dir.Opposite(), false)
+}
+
+// crossingPassage returns the cell and direction of the diagonal passage that
+// would cross the one leaving cell in the diagonal direction dir, or nil at the
+// edge of the maze. The two passages share the corner between four cells.
+func (m *Maze) crossingPassage(cell *Cell, dir Direction) (*Cell, Direction) {
+	dx, dy := dir.Offset()
+	crossingDir, _ := directionOf(-dx, dy)
+	return m.GetCell(cell.X+dx, cell.Y), crossingDir
+}
+
+// SetFinish makes p the maze's only goal, clearing any multi-goal Finishes so
+// that Finish and AllFinishes cannot disagree
+func (m *Maze) SetFinish(p Point) {
+	m.Finish = p
+	m.Finishes = nil
+}
+
+// SetFinishes sets every goal of a multi-goal maze, with Finish set to the first
+func (m *Maze) SetFinishes(finishes []Point) {
+	m.Finishes = append([]Point(nil), finishes...)
+	if len(finishes) > 0 {
+		m.Finish = finishes[0]
+	}
+}
+
+// AllFinishes returns every goal of the maze: Finishes if set, otherwise just Finish
+func (m *Maze) AllFinishes() []Point {
+	if len(m.Finishes) > 0 {
+		return m.Finishes
+	}
+	return []Point{m.Finish}
+}
+
+// isFinish reports whether the point is one of the maze's goals
+func (m *Maze) isFinish(p Point) bool {
+	for _, finish := range m.AllFinishes() {
+		if finish == p {
+			return true
+		}
+	}
+	return false
+}
+
+// isEdgeOpening reports whether the given side of a cell is an outer edge left
+// open because the start or finish sits there
+func isEdgeOpening(maze *Maze, cell *Cell, dir Direction) bool {
+	// Check if this cell is the start or finish position
+	isStart := (cell.X == maze.Start.X && cell.Y == maze.Start.Y)
+	isFinish := maze.isFinish(Point{cell.X, cell.Y})
+	if !isStart && !isFinish {
+		return false
+	}
+
+	// Only the outer wall on the edge the cell touches is open; in a masked
+	// maze the edge of the shape counts as well
+	neighbor := maze.GetNeighbor(cell, dir)
+	return neighbor == nil || neighbor.Disabled
+}
 
-	if dx == 1 { // cell2 is to the east of cell1
-		cell1.Walls[East] = false
-		cell2.Walls[West] = false
-	} else if dx == -1 { // cell2 is to the west of cell1
-		cell1.Walls[West] = false
-		cell2.Walls[East] = false
-	} else if dy == 1 { // cell2 is to the south of cell1
-		cell1.Walls[South] = false
-		cell2.Walls[North] = false
-	} else if dy == -1 { // cell2 is to the north of cell1
-		cell1.Walls[North] = false
-		cell2.Walls[South] = false
+// isMasked reports whether any cell of the maze is disabled
+func (m *Maze) isMasked() bool {
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			if m.Cells[y][x].Disabled {
+				return true
+			}
+		}
 	}
+	return false
 }
 
 // CanMove checks if movement is possible from one cell to another
@@ -112,51 +403,162 @@ func (m *Maze) CanMove(from, to *Cell) bool {
 		return false
 	}
 
-	dx := to.X - from.X
-	dy := to.Y - from.Y
+	// Cells must be adjacent, diagonally only in a diagonal maze
+	dir, ok := directionOf(to.X-from.X, to.Y-from.Y)
+	if !ok || (dir.IsDiagonal() && !m.Diagonal) {
+		return false
+	}
+	return !from.HasWall(dir)
+}
+
+// Clone returns a deep copy of the maze, so the copy can be modified without affecting the original
+func (m *Maze) Clone() *Maze {
+	clone := NewMaze(m.Width, m.Height)
+	clone.Diagonal = m.Diagonal
+	clone.Start = m.Start
+	clone.Finish = m.Finish
+	clone.Finishes = append([]Point(nil), m.Finishes...)
 
-	// Check if cells are adjacent
-	if (dx == 0 && (dy == 1 || dy == -1)) || (dy == 0 && (dx == 1 || dx == -1)) {
-		if dx == 1 { // moving east
-			return !from.Walls[East]
-		} else if dx == -1 { // moving west
-			return !from.Walls[West]
-		} else if dy == 1 { // moving south
-			return !from.Walls[South]
-		} else if dy == -1 { // moving north
-			return !from.Walls[North]
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			src, dst := m.Cells[y][x], clone.Cells[y][x]
+			dst.Visited = src.Visited
+			dst.Disabled = src.Disabled
+			dst.Walls = src.Walls
+		}
+	}
+
+	return clone
+}
+
+// ResetVisited clears the Visited flag on every cell so visited-based traversals can reuse the maze
+func (m *Maze) ResetVisited() {
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			m.Cells[y][x].Visited = false
 		}
 	}
-	return false
+}
+
+// Equal reports whether two mazes have the same dimensions, start, finishes, mask, and walls.
+// Walls are compared direction by direction, so bits outside the sides the maze
+// uses are ignored, as are Visited flags.
+func (m *Maze) Equal(other *Maze) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m.Width != other.Width || m.Height != other.Height || m.Diagonal != other.Diagonal ||
+		m.Start != other.Start || m.Finish != other.Finish {
+		return false
+	}
+	if len(m.Finishes) != len(other.Finishes) {
+		return false
+	}
+	for i := range m.Finishes {
+		if m.Finishes[i] != other.Finishes[i] {
+			return false
+		}
+	}
+
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			a, b := m.Cells[y][x], other.Cells[y][x]
+			if a.Disabled != b.Disabled {
+				return false
+			}
+			for _, dir := range m.directions() {
+				if a.HasWall(dir) != b.HasWall(dir) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
 }
 
 // RenderConfig holds configuration for rendering the maze
 type RenderConfig struct {
-	CellSize       int
-	WallThickness  int
-	ImageWidth     int
-	ImageHeight    int
-	Padding        int
-	HeaderHeight   int
-	LegendFontSize int    // Font size multiplier for legend text
-	FontPath       string // Path to TrueType font file (optional)
-	WallColor      color.Color
-	PathColor      color.Color
-	TextColor      color.Color
+	CellSize         int // Width and height of each square grid cell
+	CellWidth        int // Overrides the cell width for rectangular cells (0 = CellSize)
+	CellHeight       int // Overrides the cell height for rectangular cells (0 = CellSize)
+	WallThickness    int
+	ImageWidth       int // Maximum image width; cells shrink or grow to fit (0 = size from CellSize)
+	ImageHeight      int // Maximum image height; cells shrink or grow to fit (0 = size from CellSize)
+	Padding          int
+	HeaderHeight     int
+	LegendFontSize   int    // Font size multiplier for legend text
+	FontPath         string // Path to TrueType font file (optional)
+	ScaleBar         bool   // Draw a scale bar in a footer below the maze
+	ScaleLabel       string // Physical length represented by the scale bar (e.g. "10 ft")
+	ShowCoordinates  bool   // Label each cell with its (x,y) position for debugging
+	ShowLegend       bool   // Draw the legend header above the maze
+	ShowGrid         bool   // Draw faint guide lines at every cell boundary beneath the walls
+	RoundedCorners   bool   // Round wall ends and the outside of corners in PNG output
+	AntialiasMarkers bool   // Smooth the edges of circle and square markers in PNG output
+	ShadeSolution    bool   // Fill the cells of the solution path with ShadeColor in PNG output
+	HandDrawn        bool   // Draw walls as wobbly pen strokes in PNG output
+	HandDrawnJitter  int    // Largest offset of a hand-drawn wall from its true position in pixels (0 = half of WallThickness)
+	StartMarker      MarkerShape
+	FinishMarker     MarkerShape
+	StartLabel       string // Text drawn beside the start marker in PNG output, such as "START" (empty = none)
+	FinishLabel      string // Text drawn beside each finish marker in PNG output (empty = none)
+	WallColor        color.Color
+	PathColor        color.Color
+	TextColor        color.Color
+	HeaderColor      color.Color // Background of the legend header (defaults to BorderColor, then PathColor)
+	BorderColor      color.Color // Background of the padding around the maze (defaults to PathColor)
+	JunctionColor    color.Color // Color of junction hint dots
+	GridColor        color.Color // Color of the guide grid lines
+	SolutionColor    color.Color // Color of the solution path overlay
+	ShadeColor       color.Color // Translucent fill of solution cells when ShadeSolution is set
+	HeatmapNearColor color.Color // Heat map color for cells closest to the start
+	HeatmapFarColor  color.Color // Heat map color for cells farthest from the start
+
+	// BackgroundGradient fills the background with a vertical blend from the
+	// first color at the top to the second at the bottom instead of PathColor
+	BackgroundGradient *[2]color.Color
+
+	// CompressionLevel trades PNG encoding speed for file size. The zero value
+	// is png.DefaultCompression; png.BestCompression shrinks the mostly blank
+	// maze images considerably at the cost of slower encoding. There is no WebP
+	// output since the standard library and x/image only decode WebP.
+	CompressionLevel png.CompressionLevel
+
+	// SolutionThickness is the width of path overlay lines in pixels (0 = WallThickness)
+	SolutionThickness int
+
+	// SolutionArrowSpacing draws an arrowhead every this many cells along each
+	// path overlay, pointing from its first cell toward its last (0 = no arrows)
+	SolutionArrowSpacing int
+
+	// SolutionWallColor, when set, redraws the walls around the cells of the
+	// solution path in this color in PNG output, outlining the corridor
+	SolutionWallColor color.Color
 }
 
 // DefaultRenderConfig returns a default configuration optimized for 8.5"x11" printing
 func DefaultRenderConfig() RenderConfig {
 	return RenderConfig{
-		CellSize:       84,                             // Size of each cell in pixels
-		WallThickness:  8,                              // Thickness of walls in pixels
-		ImageWidth:     2100,                           // ~7" at 300 DPI
-		ImageHeight:    2700,                           // ~9" at 300 DPI
-		Padding:        100,                            // Padding around the maze in pixels
-		HeaderHeight:   120,                            // Height of header area for legend (increased for larger font)
-		LegendFontSize: 3,                              // 3x font size multiplier
-		WallColor:      color.RGBA{0, 0, 0, 255},       // Black
-		PathColor:      color.RGBA{255, 255, 255, 255}, // White
-		TextColor:      color.RGBA{0, 0, 0, 255},       // Black text
+		CellSize:         84,                             // Size of each cell in pixels
+		WallThickness:    8,                              // Thickness of walls in pixels
+		ImageWidth:       0,                              // Size from CellSize; 2100 fits ~7" at 300 DPI
+		ImageHeight:      0,                              // Size from CellSize; 2700 fits ~9" at 300 DPI
+		Padding:          100,                            // Padding around the maze in pixels
+		HeaderHeight:     120,                            // Height of header area for legend (increased for larger font)
+		LegendFontSize:   3,                              // 3x font size multiplier
+		ShowLegend:       true,                           // Legend header shown above the maze
+		StartMarker:      Circle,                         // Circle marks the start
+		FinishMarker:     Square,                         // Square marks the finish
+		WallColor:        color.RGBA{0, 0, 0, 255},       // Black
+		PathColor:        color.RGBA{255, 255, 255, 255}, // White
+		TextColor:        color.RGBA{0, 0, 0, 255},       // Black text
+		HeaderColor:      color.RGBA{255, 255, 255, 255}, // White, same as paths
+		JunctionColor:    color.RGBA{180, 180, 180, 255}, // Light gray
+		GridColor:        color.RGBA{220, 220, 220, 255}, // Very light gray
+		SolutionColor:    color.RGBA{220, 20, 60, 255},   // Crimson
+		ShadeColor:       color.NRGBA{220, 20, 60, 56},   // Faint crimson
+		HeatmapNearColor: color.RGBA{40, 80, 220, 255},   // Blue
+		HeatmapFarColor:  color.RGBA{220, 40, 40, 255},   // Red
 	}
 }
@@ -0,0 +1,86 @@
+package maze
+
+import "testing"
+
+func TestCloneIsIndependent(t *testing.T) {
+	original := NewGeneratorWithSeed(1).Generate(6, 5)
+	original.Start = Point{0, 0}
+	original.Finish = Point{5, 4}
+	before := NewDefaultRenderer().RenderToASCII(original)
+
+	clone := original.Clone()
+	if !clone.Equal(original) {
+		t.Fatal("clone differs from the original before mutation")
+	}
+
+	// Mutate walls, flags, and endpoints of the clone
+	for y := 0; y < clone.Height; y++ {
+		for x := 0; x < clone.Width; x++ {
+			cell := clone.GetCell(x, y)
+			cell.Walls = 0
+			cell.Visited = !cell.Visited
+		}
+	}
+	clone.GetCell(2, 2).Disabled = true
+	clone.Start = Point{3, 3}
+	clone.Finish = Point{1, 1}
+	clone.SetFinishes([]Point{{1, 1}, {4, 0}})
+
+	if got := NewDefaultRenderer().RenderToASCII(original); got != before {
+		t.Errorf("original changed after mutating the clone\ngot:\n%s\nwant:\n%s", got, before)
+	}
+	if original.GetCell(2, 2).Disabled {
+		t.Error("disabling a clone cell disabled the original cell")
+	}
+	if original.Start != (Point{0, 0}) || original.Finish != (Point{5, 4}) || original.Finishes != nil {
+		t.Errorf("original endpoints changed: Start %v, Finish %v, Finishes %v", original.Start, original.Finish, original.Finishes)
+	}
+}
+
+// mapCell mirrors the map-based Cell layout that the Walls bitmask replaced,
+// so the benchmarks below can compare the two
+type mapCell struct {
+	X, Y    int
+	Visited bool
+	Walls   map[Direction]bool
+}
+
+func BenchmarkCellWallsMap(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		cells := make([]*mapCell, 0, 50*50)
+		for i := range 50 * 50 {
+			cells = append(cells, &mapCell{X: i % 50, Y: i / 50, Walls: map[Direction]bool{
+				North: true, East: true, South: true, West: true,
+			}})
+		}
+		open := 0
+		for _, cell := range cells {
+			cell.Walls[East] = false
+			for _, dir := range AllDirections() {
+				if !cell.Walls[dir] {
+					open++
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkCellWallsBitmask(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		cells := make([]*Cell, 0, 50*50)
+		for i := range 50 * 50 {
+			cells = append(cells, NewCell(i%50, i/50))
+		}
+		open := 0
+		for _, cell := range cells {
+			cell.SetWall(East, false)
+			for _, dir := range AllDirections() {
+				if !cell.HasWall(dir) {
+					open++
+				}
+			}
+		}
+	}
+}
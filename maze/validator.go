@@ -1,5 +1,10 @@
 package maze
 
+import (
+	"container/heap"
+	"math"
+)
+
 // Validator handles maze validation using pathfinding algorithms
 type Validator struct{}
 
@@ -29,10 +34,112 @@ func (v *Validator) HasPath(maze *Maze) bool {
 	return v.bfsPath(maze, startCell, finishCell)
 }
 
+// Connected checks whether there is a path between two arbitrary points.
+// It returns false if either point lies outside the maze.
+func (v *Validator) Connected(maze *Maze, a, b Point) bool {
+	if maze == nil {
+		return false
+	}
+
+	from := maze.GetCell(a.X, a.Y)
+	to := maze.GetCell(b.X, b.Y)
+	if from == nil || to == nil {
+		return false
+	}
+
+	return v.bfsPath(maze, from, to)
+}
+
+// HasPathToAll checks that every finish of the maze is reachable from the start
+func (v *Validator) HasPathToAll(maze *Maze) bool {
+	if maze == nil {
+		return false
+	}
+
+	distances := v.bfsDistances(maze, maze.Start)
+	for _, finish := range maze.AllFinishes() {
+		if _, ok := distances[finish]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPerfect checks that the maze is a spanning tree: every enabled cell is
+// reachable and there is exactly one passage fewer than there are enabled
+// cells, so there are no loops
+func (v *Validator) IsPerfect(maze *Maze) bool {
+	if maze == nil || maze.Width <= 0 || maze.Height <= 0 {
+		return false
+	}
+
+	// Count each interior passage once through its east or south side,
+	// or its south-east or south-west side in a diagonal maze
+	passages, total := 0, 0
+	var first *Point
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			cell := maze.GetCell(x, y)
+			if cell.Disabled {
+				continue
+			}
+			total++
+			if first == nil {
+				first = &Point{x, y}
+			}
+			forward := []Direction{East, South}
+			if maze.Diagonal {
+				forward = append(forward, SouthEast, SouthWest)
+			}
+			for _, dir := range forward {
+				if maze.GetNeighbor(cell, dir) != nil && !cell.HasWall(dir) {
+					passages++
+				}
+			}
+		}
+	}
+
+	if first == nil || passages != total-1 {
+		return false
+	}
+	return len(v.bfsDistances(maze, *first)) == total
+}
+
+// OpenRegions returns the top-left cell of every 2x2 block whose four internal
+// walls are all removed. Such open rooms contain a loop, so perfect-maze
+// algorithms should never produce them; for braided or room-based mazes the
+// result is diagnostic only.
+func (v *Validator) OpenRegions(maze *Maze) []Point {
+	if maze == nil {
+		return nil
+	}
+
+	var regions []Point
+	for y := 0; y+1 < maze.Height; y++ {
+		for x := 0; x+1 < maze.Width; x++ {
+			topLeft, topRight := maze.GetCell(x, y), maze.GetCell(x+1, y)
+			bottomLeft, bottomRight := maze.GetCell(x, y+1), maze.GetCell(x+1, y+1)
+			if maze.CanMove(topLeft, topRight) && maze.CanMove(topLeft, bottomLeft) &&
+				maze.CanMove(topRight, bottomRight) && maze.CanMove(bottomLeft, bottomRight) {
+				regions = append(regions, Point{x, y})
+			}
+		}
+	}
+	return regions
+}
+
 // bfsPath performs breadth-first search to find a path between two cells
 func (v *Validator) bfsPath(maze *Maze, start, finish *Cell) bool {
+	order := v.bfsVisitOrder(maze, start, finish)
+	return order[len(order)-1] == Point{finish.X, finish.Y}
+}
+
+// bfsVisitOrder performs breadth-first search from start and returns the cells
+// in the order they were dequeued, ending early once finish is reached
+func (v *Validator) bfsVisitOrder(maze *Maze, start, finish *Cell) []Point {
 	// Keep track of visited cells
 	visited := make(map[Point]bool)
+	var order []Point
 
 	// Queue for BFS - stores cells to visit
 	queue := []*Cell{start}
@@ -42,15 +149,15 @@ func (v *Validator) bfsPath(maze *Maze, start, finish *Cell) bool {
 		// Dequeue the first cell
 		current := queue[0]
 		queue = queue[1:]
+		order = append(order, Point{current.X, current.Y})
 
 		// Check if we reached the finish
 		if current.X == finish.X && current.Y == finish.Y {
-			return true
+			return order
 		}
 
-		// Check all four directions
-		directions := []Direction{North, East, South, West}
-		for _, dir := range directions {
+		// Check each direction the maze connects in
+		for _, dir := range maze.directions() {
 			neighbor := maze.GetNeighbor(current, dir)
 			if neighbor != nil {
 				neighborPoint := Point{neighbor.X, neighbor.Y}
@@ -64,8 +171,8 @@ func (v *Validator) bfsPath(maze *Maze, start, finish *Cell) bool {
 		}
 	}
 
-	// No path found
-	return false
+	// No path found; every reachable cell was visited
+	return order
 }
 
 // FindPath returns the actual path from start to finish (for debugging/visualization)
@@ -89,6 +196,22 @@ func (v *Validator) FindPath(maze *Maze) []Point {
 	return v.bfsPathWithTrace(maze, startCell, finishCell)
 }
 
+// Directions returns the moves that lead from start to finish along the shortest path.
+// It returns an empty, non-nil slice when the start is the finish, and nil if no path exists.
+func (v *Validator) Directions(maze *Maze) []Direction {
+	path := v.FindPath(maze)
+	if len(path) == 0 {
+		return nil
+	}
+
+	directions := make([]Direction, 0, len(path)-1)
+	for i := 1; i < len(path); i++ {
+		dir, _ := directionOf(path[i].X-path[i-1].X, path[i].Y-path[i-1].Y)
+		directions = append(directions, dir)
+	}
+	return directions
+}
+
 // bfsPathWithTrace performs BFS and returns the actual path
 func (v *Validator) bfsPathWithTrace(maze *Maze, start, finish *Cell) []Point {
 	// Keep track of visited cells and their parents
@@ -112,9 +235,8 @@ func (v *Validator) bfsPathWithTrace(maze *Maze, start, finish *Cell) []Point {
 			return v.reconstructPath(parent, startPoint, Point{finish.X, finish.Y})
 		}
 
-		// Check all four directions
-		directions := []Direction{North, East, South, West}
-		for _, dir := range directions {
+		// Check each direction the maze connects in
+		for _, dir := range maze.directions() {
 			neighbor := maze.GetNeighbor(current, dir)
 			if neighbor != nil {
 				neighborPoint := Point{neighbor.X, neighbor.Y}
@@ -149,3 +271,316 @@ func (v *Validator) reconstructPath(parent map[Point]Point, start, finish Point)
 
 	return path
 }
+
+// RepairConnectivity reconnects isolated regions of the maze by removing the
+// minimum number of walls needed to join every enabled cell into one component.
+// Disabled cells keep all their walls, so regions of a masked maze that only
+// touch across disabled cells stay separate. It returns the number of walls
+// that were removed.
+func (v *Validator) RepairConnectivity(maze *Maze) int {
+	if maze == nil {
+		return 0
+	}
+
+	regions := v.RegionCount(maze)
+	if regions <= 1 {
+		return 0
+	}
+
+	// Track which components have been merged together
+	labels, count := v.labelComponents(maze)
+	sets := newDisjointSet(count)
+
+	removed := 0
+	for y := 0; y < maze.Height && removed < regions-1; y++ {
+		for x := 0; x < maze.Width && removed < regions-1; x++ {
+			cell := maze.GetCell(x, y)
+			if cell.Disabled {
+				continue
+			}
+
+			// Only look east and south so each wall is considered once
+			for _, dir := range []Direction{East, South} {
+				neighbor := maze.GetNeighbor(cell, dir)
+				if neighbor == nil || neighbor.Disabled {
+					continue
+				}
+
+				if sets.union(labels[Point{cell.X, cell.Y}], labels[Point{neighbor.X, neighbor.Y}]) {
+					maze.RemoveWall(cell, neighbor)
+					removed++
+				}
+			}
+		}
+	}
+
+	return removed
+}
+
+// RegionCount returns the number of connected regions among the enabled cells.
+// A perfect maze, or a masked maze whose shape is fully carved, has exactly one;
+// more indicate isolated areas. Disabled cells are not counted.
+func (v *Validator) RegionCount(maze *Maze) int {
+	if maze == nil {
+		return 0
+	}
+
+	labels, _ := v.labelComponents(maze)
+	regions := make(map[int]bool)
+	for p, label := range labels {
+		if !maze.GetCell(p.X, p.Y).Disabled {
+			regions[label] = true
+		}
+	}
+	return len(regions)
+}
+
+// labelComponents assigns a component index to every cell using BFS flood fill
+// and returns the labels along with the number of components found
+func (v *Validator) labelComponents(maze *Maze) (map[Point]int, int) {
+	labels := make(map[Point]int)
+	count := 0
+
+	for y := 0; y < maze.Height; y++ {
+		for x := 0; x < maze.Width; x++ {
+			if _, seen := labels[Point{x, y}]; seen {
+				continue
+			}
+
+			// Flood fill a new component from this cell
+			queue := []*Cell{maze.GetCell(x, y)}
+			labels[Point{x, y}] = count
+
+			for len(queue) > 0 {
+				current := queue[0]
+				queue = queue[1:]
+
+				for _, dir := range maze.directions() {
+					neighbor := maze.GetNeighbor(current, dir)
+					if neighbor == nil {
+						continue
+					}
+
+					neighborPoint := Point{neighbor.X, neighbor.Y}
+					if _, seen := labels[neighborPoint]; !seen && maze.CanMove(current, neighbor) {
+						labels[neighborPoint] = count
+						queue = append(queue, neighbor)
+					}
+				}
+			}
+
+			count++
+		}
+	}
+
+	return labels, count
+}
+
+// ShortestPathCount returns the number of distinct shortest paths from start
+// to finish, or 0 if there is no path. The count saturates at math.MaxInt for
+// heavily looped mazes.
+func (v *Validator) ShortestPathCount(maze *Maze) int {
+	if maze == nil || maze.GetCell(maze.Finish.X, maze.Finish.Y) == nil {
+		return 0
+	}
+	distances := v.bfsDistances(maze, maze.Start)
+	if _, ok := distances[maze.Finish]; !ok {
+		return 0
+	}
+
+	// Group reachable cells by distance so each is counted after its predecessors
+	layers := make([][]Point, distances[maze.Finish]+1)
+	for p, d := range distances {
+		if d < len(layers) {
+			layers[d] = append(layers[d], p)
+		}
+	}
+
+	ways := map[Point]int{maze.Start: 1}
+	for d := 1; d < len(layers); d++ {
+		for _, p := range layers[d] {
+			cell := maze.GetCell(p.X, p.Y)
+			for _, dir := range maze.directions() {
+				neighbor := maze.GetNeighbor(cell, dir)
+				if neighbor == nil || !maze.CanMove(neighbor, cell) {
+					continue
+				}
+				prev := Point{neighbor.X, neighbor.Y}
+				if pd, ok := distances[prev]; ok && pd == d-1 {
+					ways[p] = saturatingAdd(ways[p], ways[prev])
+				}
+			}
+		}
+	}
+
+	return ways[maze.Finish]
+}
+
+// saturatingAdd adds two non-negative counts, capping the result at math.MaxInt
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+// bfsDistances floods the maze from the given cell and returns the number of
+// steps to every reachable cell
+func (v *Validator) bfsDistances(maze *Maze, from Point) map[Point]int {
+	distances := make(map[Point]int)
+
+	start := maze.GetCell(from.X, from.Y)
+	if start == nil {
+		return distances
+	}
+
+	// Queue for BFS
+	queue := []*Cell{start}
+	distances[from] = 0
+
+	for len(queue) > 0 {
+		// Dequeue the first cell
+		current := queue[0]
+		queue = queue[1:]
+		currentDistance := distances[Point{current.X, current.Y}]
+
+		// Check each direction the maze connects in
+		for _, dir := range maze.directions() {
+			neighbor := maze.GetNeighbor(current, dir)
+			if neighbor != nil {
+				neighborPoint := Point{neighbor.X, neighbor.Y}
+
+				// If we haven't visited this neighbor and can move to it
+				if _, seen := distances[neighborPoint]; !seen && maze.CanMove(current, neighbor) {
+					distances[neighborPoint] = currentDistance + 1
+					queue = append(queue, neighbor)
+				}
+			}
+		}
+	}
+
+	return distances
+}
+
+// FindPathAStar returns the shortest path from start to finish using A* search
+// with the Manhattan distance heuristic, or the Chebyshev distance in a diagonal
+// maze. Since the heuristic never overestimates, the path has the same length
+// as the one returned by FindPath.
+func (v *Validator) FindPathAStar(maze *Maze) []Point {
+	if maze == nil {
+		return nil
+	}
+
+	startCell := maze.GetCell(maze.Start.X, maze.Start.Y)
+	finishCell := maze.GetCell(maze.Finish.X, maze.Finish.Y)
+
+	if startCell == nil || finishCell == nil {
+		return nil
+	}
+
+	// If start and finish are the same cell
+	if maze.Start.X == maze.Finish.X && maze.Start.Y == maze.Finish.Y {
+		return []Point{maze.Start}
+	}
+
+	return v.aStarPath(maze, startCell, finishCell)
+}
+
+// aStarPath performs A* search and returns the path between two cells
+func (v *Validator) aStarPath(maze *Maze, start, finish *Cell) []Point {
+	startPoint := Point{start.X, start.Y}
+	finishPoint := Point{finish.X, finish.Y}
+
+	// Cost from start to each discovered cell and the cell it was reached from
+	cost := map[Point]int{startPoint: 0}
+	parent := make(map[Point]Point)
+	closed := make(map[Point]bool)
+
+	heuristic := manhattan
+	if maze.Diagonal {
+		heuristic = chebyshev
+	}
+
+	open := &aStarQueue{}
+	heap.Push(open, aStarItem{point: startPoint, priority: heuristic(startPoint, finishPoint)})
+
+	for open.Len() > 0 {
+		currentPoint := heap.Pop(open).(aStarItem).point
+		if closed[currentPoint] {
+			continue
+		}
+		closed[currentPoint] = true
+
+		// Check if we reached the finish
+		if currentPoint == finishPoint {
+			return v.reconstructPath(parent, startPoint, finishPoint)
+		}
+
+		current := maze.GetCell(currentPoint.X, currentPoint.Y)
+
+		// Check each direction the maze connects in
+		for _, dir := range maze.directions() {
+			neighbor := maze.GetNeighbor(current, dir)
+			if neighbor == nil || !maze.CanMove(current, neighbor) {
+				continue
+			}
+
+			neighborPoint := Point{neighbor.X, neighbor.Y}
+			newCost := cost[currentPoint] + 1
+			if known, seen := cost[neighborPoint]; !seen || newCost < known {
+				cost[neighborPoint] = newCost
+				parent[neighborPoint] = currentPoint
+				heap.Push(open, aStarItem{point: neighborPoint, priority: newCost + heuristic(neighborPoint, finishPoint)})
+			}
+		}
+	}
+
+	// No path found
+	return nil
+}
+
+// manhattan returns the Manhattan distance between two points
+func manhattan(a, b Point) int {
+	dx := a.X - b.X
+	if dx < 0 {
+		dx = -dx
+	}
+	dy := a.Y - b.Y
+	if dy < 0 {
+		dy = -dy
+	}
+	return dx + dy
+}
+
+// chebyshev returns the number of king moves between two points, counting a diagonal step as one
+func chebyshev(a, b Point) int {
+	dx := a.X - b.X
+	if dx < 0 {
+		dx = -dx
+	}
+	dy := a.Y - b.Y
+	if dy < 0 {
+		dy = -dy
+	}
+	return max(dx, dy)
+}
+
+// aStarItem is an entry in the A* open set
+type aStarItem struct {
+	point    Point
+	priority int
+}
+
+// aStarQueue is a min-heap of A* entries ordered by priority
+type aStarQueue []aStarItem
+
+func (q aStarQueue) Len() int           { return len(q) }
+func (q aStarQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q aStarQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *aStarQueue) Push(x any)        { *q = append(*q, x.(aStarItem)) }
+func (q *aStarQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,129 @@
+package maze
+
+import "testing"
+
+// testMaze returns a validated maze for the given seed and size, failing the test on error
+func testMaze(t testing.TB, seed int64, width, height int) *Maze {
+	t.Helper()
+	m, err := NewGeneratorWithSeed(seed).GenerateWithValidation(width, height, defaultRetries)
+	if err != nil {
+		t.Fatalf("seed %d: %v", seed, err)
+	}
+	return m
+}
+
+func TestFindPathAStarMatchesBFS(t *testing.T) {
+	v := NewValidator()
+	for _, seed := range testSeeds {
+		m := testMaze(t, seed, 20, 15)
+		bfs, astar := v.FindPath(m), v.FindPathAStar(m)
+		if len(bfs) == 0 {
+			t.Fatalf("seed %d: FindPath found no path", seed)
+		}
+		if len(astar) != len(bfs) {
+			t.Errorf("seed %d: FindPathAStar length %d, FindPath length %d", seed, len(astar), len(bfs))
+		}
+	}
+}
+
+func BenchmarkFindPath(b *testing.B) {
+	m := testMaze(b, 1, 100, 100)
+	v := NewValidator()
+	for b.Loop() {
+		v.FindPath(m)
+	}
+}
+
+func BenchmarkFindPathAStar(b *testing.B) {
+	m := testMaze(b, 1, 100, 100)
+	v := NewValidator()
+	for b.Loop() {
+		v.FindPathAStar(m)
+	}
+}
+
+func TestDirectionsLeadFromStartToFinish(t *testing.T) {
+	v := NewValidator()
+	for _, seed := range testSeeds {
+		m := testMaze(t, seed, 15, 10)
+		pos := m.Start
+		for i, dir := range v.Directions(m) {
+			current := m.GetCell(pos.X, pos.Y)
+			next := m.GetNeighbor(current, dir)
+			if next == nil || !m.CanMove(current, next) {
+				t.Fatalf("seed %d: move %d (%v) from %v is blocked", seed, i, dir, pos)
+			}
+			pos = Point{next.X, next.Y}
+		}
+		if pos != m.Finish {
+			t.Errorf("seed %d: directions end at %v, want finish %v", seed, pos, m.Finish)
+		}
+	}
+}
+
+func TestDirectionsAlreadyAtFinish(t *testing.T) {
+	m := NewGeneratorWithSeed(1).Generate(3, 3)
+	m.Start = Point{1, 1}
+	m.Finish = Point{1, 1}
+	if got := NewValidator().Directions(m); got == nil || len(got) != 0 {
+		t.Errorf("Directions with Start == Finish = %#v, want an empty non-nil slice", got)
+	}
+}
+
+// ringMask returns a size by size mask with its center cell disabled
+func ringMask(size int) [][]bool {
+	mask := make([][]bool, size)
+	for y := range mask {
+		mask[y] = make([]bool, size)
+		for x := range mask[y] {
+			mask[y][x] = x != size/2 || y != size/2
+		}
+	}
+	return mask
+}
+
+// checkDisabledWalls fails the test if any disabled cell of m has lost a wall
+func checkDisabledWalls(t *testing.T, name string, m *Maze) {
+	t.Helper()
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			if cell := m.Cells[y][x]; cell.Disabled && cell.Walls&allWalls != allWalls {
+				t.Errorf("%s: disabled cell (%d, %d) lost a wall", name, x, y)
+			}
+		}
+	}
+}
+
+func TestRepairConnectivityKeepsMaskedCellsWalled(t *testing.T) {
+	v := NewValidator()
+	for _, seed := range testSeeds {
+		m := NewGeneratorWithSeed(seed).GenerateMasked(ringMask(7))
+
+		// Cut the shape into several regions, then repair it
+		for y := 0; y < m.Height; y++ {
+			for x := 0; x < m.Width; x++ {
+				for _, dir := range AllDirections() {
+					m.Cells[y][x].SetWall(dir, true)
+				}
+			}
+		}
+		v.RepairConnectivity(m)
+
+		checkDisabledWalls(t, "RepairConnectivity", m)
+		if n := v.RegionCount(m); n != 1 {
+			t.Errorf("seed %d: %d regions after repair, want 1", seed, n)
+		}
+	}
+}
+
+func TestComplementWallsKeepsMask(t *testing.T) {
+	m := NewGeneratorWithSeed(1).GenerateMasked(ringMask(7))
+	complement := NewTransformer().ComplementWalls(m)
+	if !complement.GetCell(3, 3).Disabled {
+		t.Error("ComplementWalls enabled the masked-out cell")
+	}
+	checkDisabledWalls(t, "ComplementWalls", complement)
+	if n := NewValidator().RegionCount(complement); n != 1 {
+		t.Errorf("complement has %d regions, want 1", n)
+	}
+}
@@ -0,0 +1,215 @@
+package maze
+
+import (
+	"image"
+	"image/draw"
+)
+
+// WeaveMaze is a maze whose passages may cross over and under each other.
+// The underlying Maze stores the walls as usual, with every side of a crossing
+// cell open. Crossings records the axis of the passage tunneling under each
+// crossing cell: North for a north-south tunnel beneath an east-west corridor,
+// East for an east-west tunnel beneath a north-south corridor.
+type WeaveMaze struct {
+	*Maze
+	Crossings map[Point]Direction
+}
+
+// NewWeaveMaze creates a new weave maze with all walls intact and no crossings
+func NewWeaveMaze(width, height int) *WeaveMaze {
+	return &WeaveMaze{
+		Maze:      NewMaze(width, height),
+		Crossings: make(map[Point]Direction),
+	}
+}
+
+// axis returns the representative direction of the axis dir lies on, North or East
+func axis(dir Direction) Direction {
+	return dir % 2
+}
+
+// Moves returns the points reachable in one move from p.
+// Moving toward a crossing along its tunnel passes underneath and lands on the
+// far side, and a walker on a crossing can only continue along the corridor on top.
+func (m *WeaveMaze) Moves(p Point) []Point {
+	cell := m.GetCell(p.X, p.Y)
+	if cell == nil {
+		return nil
+	}
+	tunnel, onCrossing := m.Crossings[p]
+
+	var moves []Point
+	for _, dir := range AllDirections() {
+		if cell.HasWall(dir) || (onCrossing && axis(dir) == tunnel) {
+			continue
+		}
+
+		next := m.GetNeighbor(cell, dir)
+		if next == nil {
+			continue
+		}
+		if under, ok := m.Crossings[Point{next.X, next.Y}]; ok && under == axis(dir) {
+			next = m.GetNeighbor(next, dir)
+		}
+		moves = append(moves, Point{next.X, next.Y})
+	}
+	return moves
+}
+
+// GenerateWeave creates a new weave maze using recursive backtracking.
+// When the carver meets a visited straight corridor running across its path,
+// it may tunnel underneath to an unvisited cell on the other side; weaveChance
+// (0 to 1) is the probability that each such tunnel is considered. The start is
+// placed in the top-left corner and the finish in the bottom-right, which are
+// always connected since the result is still a perfect maze.
+func (g *Generator) GenerateWeave(width, height int, weaveChance float64) *WeaveMaze {
+	maze := NewWeaveMaze(width, height)
+
+	// A step carves into target, passing under via when it is a tunnel
+	type move struct {
+		target, via *Cell
+		dir         Direction
+	}
+
+	// Start from a random cell and backtrack with an explicit stack
+	start := maze.GetCell(g.rng.Intn(width), g.rng.Intn(height))
+	start.Visited = true
+	stack := []*Cell{start}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+
+		var moves []move
+		for _, dir := range AllDirections() {
+			neighbor := maze.GetNeighbor(current, dir)
+			if neighbor == nil {
+				continue
+			}
+			if !neighbor.Visited {
+				moves = append(moves, move{target: neighbor})
+				continue
+			}
+			if beyond := tunnelTarget(maze, current, neighbor, dir); beyond != nil && g.rng.Float64() < weaveChance {
+				moves = append(moves, move{target: beyond, via: neighbor, dir: dir})
+			}
+		}
+
+		if len(moves) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		next := moves[g.rng.Intn(len(moves))]
+		if next.via != nil {
+			// Open both ends of the tunnel and record the crossing
+			maze.RemoveWall(current, next.via)
+			maze.RemoveWall(next.via, next.target)
+			maze.Crossings[Point{next.via.X, next.via.Y}] = axis(next.dir)
+		} else {
+			maze.RemoveWall(current, next.target)
+		}
+		next.target.Visited = true
+		stack = append(stack, next.target)
+	}
+
+	maze.Start = Point{0, 0}
+	maze.Finish = Point{width - 1, height - 1}
+
+	return maze
+}
+
+// tunnelTarget returns the unvisited cell beyond neighbor that current could tunnel to,
+// or nil if neighbor is not a straight corridor running across the direction of travel
+func tunnelTarget(maze *WeaveMaze, current, neighbor *Cell, dir Direction) *Cell {
+	if !current.HasWall(dir) {
+		return nil
+	}
+	if _, ok := maze.Crossings[Point{neighbor.X, neighbor.Y}]; ok {
+		return nil
+	}
+
+	// The corridor must be closed along the direction of travel and open across it
+	across := (dir + 1) % 4
+	if !neighbor.HasWall(dir) || !neighbor.HasWall(dir.Opposite()) ||
+		neighbor.HasWall(across) || neighbor.HasWall(across.Opposite()) {
+		return nil
+	}
+
+	beyond := maze.GetNeighbor(neighbor, dir)
+	if beyond == nil || beyond.Visited {
+		return nil
+	}
+	return beyond
+}
+
+// HasWeavePath checks if there's a valid path from start to finish in a weave maze
+func (v *Validator) HasWeavePath(maze *WeaveMaze) bool {
+	if maze == nil || maze.GetCell(maze.Start.X, maze.Start.Y) == nil || maze.GetCell(maze.Finish.X, maze.Finish.Y) == nil {
+		return false
+	}
+
+	visited := map[Point]bool{maze.Start: true}
+	queue := []Point{maze.Start}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == maze.Finish {
+			return true
+		}
+
+		for _, next := range maze.Moves(current) {
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return false
+}
+
+// RenderWeaveToPNG renders a weave maze to a PNG file.
+// The corridor on top of each crossing keeps its side walls, while the walls
+// of the passage underneath stop short of it so the crossing reads as a bridge.
+func (r *Renderer) RenderWeaveToPNG(maze *WeaveMaze, filename string) error {
+	r = r.fitToSize(maze.Maze)
+	img := r.createImage(maze.Maze)
+
+	wallColor := &image.Uniform{r.config.WallColor}
+	pathColor := &image.Uniform{r.config.PathColor}
+	width, height := r.cellWidth(), r.cellHeight()
+	thickness := r.config.WallThickness
+	gap := thickness
+
+	for pos, tunnel := range maze.Crossings {
+		cellX := pos.X*width + r.config.Padding
+		cellY := pos.Y*height + r.config.Padding + r.headerHeight()
+
+		if tunnel == North {
+			// Railings along the east-west corridor on top
+			draw.Draw(img, r.wallRect(pos.X, pos.Y, North), wallColor, image.Point{}, draw.Src)
+			draw.Draw(img, r.wallRect(pos.X, pos.Y, South), wallColor, image.Point{}, draw.Src)
+
+			// Break the tunnel walls just above and below the railings
+			for _, x := range []int{cellX, cellX + width} {
+				draw.Draw(img, image.Rect(x, cellY-gap, x+thickness, cellY), pathColor, image.Point{}, draw.Src)
+				draw.Draw(img, image.Rect(x, cellY+height+thickness, x+thickness, cellY+height+thickness+gap), pathColor, image.Point{}, draw.Src)
+			}
+			continue
+		}
+
+		// Railings along the north-south corridor on top
+		draw.Draw(img, r.wallRect(pos.X, pos.Y, West), wallColor, image.Point{}, draw.Src)
+		draw.Draw(img, r.wallRect(pos.X, pos.Y, East), wallColor, image.Point{}, draw.Src)
+
+		// Break the tunnel walls just left and right of the railings
+		for _, y := range []int{cellY, cellY + height} {
+			draw.Draw(img, image.Rect(cellX-gap, y, cellX, y+thickness), pathColor, image.Point{}, draw.Src)
+			draw.Draw(img, image.Rect(cellX+width+thickness, y, cellX+width+thickness+gap, y+thickness), pathColor, image.Point{}, draw.Src)
+		}
+	}
+
+	return r.writePNG(img, filename)
+}